@@ -0,0 +1,13 @@
+package main
+
+// The day packages register their solvers with the runner when they are imported.
+// "aoc new" adds the import of each new day before the end marker.
+import (
+	_ "github.com/cl3mcg/aoc2024/day01"
+	_ "github.com/cl3mcg/aoc2024/day02"
+	_ "github.com/cl3mcg/aoc2024/day03"
+	_ "github.com/cl3mcg/aoc2024/day04"
+	_ "github.com/cl3mcg/aoc2024/day05"
+	_ "github.com/cl3mcg/aoc2024/day06"
+	// aoc new: end of days
+)
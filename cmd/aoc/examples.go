@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cl3mcg/aoc2024/example"
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+// runExamples extracts the example inputs and answers of the puzzle descriptions into the testdata directories.
+func runExamples(args []string) error {
+	fs := flag.NewFlagSet("examples", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to extract (default: every day)")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	prefix := fs.String("prefix", example.DefaultPrefix, "prefix of the marker comments overriding the heuristic, as in <!-- aoc:example -->")
+	dry := fs.Bool("n", false, "print the examples instead of writing them")
+	fs.Parse(args)
+
+	days := []int{*day}
+	if *day == 0 {
+		days = days[:0]
+		for d := 1; d <= 25; d++ {
+			if _, err := os.Stat(filepath.Join(*root, fmt.Sprintf("day%02d", d))); err == nil {
+				days = append(days, d)
+			}
+		}
+	}
+
+	failed := false
+	for _, d := range days {
+		for part := 1; part <= 2; part++ {
+			md, err := description(*root, d, part)
+			if err != nil {
+				return err
+			}
+			ex, err := example.Extract(md, part, *prefix)
+			if err != nil {
+				// A missing example is reported but does not stop the other days.
+				failed = true
+				fmt.Printf("day %02d part %d: %v\n", d, part, err)
+				continue
+			}
+			if *dry {
+				fmt.Printf("day %02d part %d: answer %s\n%s\n", d, part, ex.Answer, ex.Input)
+				continue
+			}
+			if err := example.Write(*root, d, ex); err != nil {
+				return err
+			}
+			fmt.Printf("day %02d part %d: answer %s, written to %s\n", d, part, ex.Answer, example.Path(*root, d, part))
+		}
+	}
+
+	if failed {
+		return errors.New("examples: some examples were not found, mark them in the README")
+	}
+	return nil
+}
+
+// description reads the README.md of the first directory of a part, e.g. dayXX/02_1 for part two.
+func description(root string, day, part int) (string, error) {
+	dirs, err := filepath.Glob(filepath.Join(root, fmt.Sprintf("day%02d", day), fmt.Sprintf("%02d_*", part)))
+	if err != nil {
+		return "", err
+	}
+	if len(dirs) == 0 {
+		return "", fmt.Errorf("examples: no directory for day %d part %d", day, part)
+	}
+	d, err := os.ReadFile(filepath.Join(dirs[0], "README.md"))
+	return string(d), err
+}
+
+// runTest solves the extracted examples with the registered solvers and compares the answers.
+func runTest(args []string) error {
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to test (default: every registered day)")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	fs.Parse(args)
+
+	ps := runner.Parts()
+	if *day > 0 {
+		ps = runner.Lookup(*day, 0)
+	}
+
+	failed := false
+	for _, p := range ps {
+		ex, err := example.Load(*root, p.Day, p.Part)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("day %02d part %d: no example, run aoc examples\n", p.Day, p.Part)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		o := runner.Run(solverContext(context.Background(), p), p, ex.Input)
+		switch got := fmt.Sprint(o.Answer); {
+		case o.Err != nil:
+			failed = true
+			fmt.Printf("day %02d part %d: FAIL: %v\n", p.Day, p.Part, o.Err)
+		case got != ex.Answer:
+			failed = true
+			fmt.Printf("day %02d part %d: FAIL: got %s, want %s\n", p.Day, p.Part, got, ex.Answer)
+		default:
+			fmt.Printf("day %02d part %d: ok (%v)\n", p.Day, p.Part, o.Elapsed)
+		}
+	}
+
+	if failed {
+		return errors.New("test: some examples failed")
+	}
+	return nil
+}
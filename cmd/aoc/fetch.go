@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/cl3mcg/aoc2024/input"
+)
+
+// runFetch downloads the input of a day, or reads it from the cache, and writes it to a file or to the standard output.
+// The session token is taken from AOC_SESSION or from the session file, see input.Session.
+func runFetch(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	day := fs.Int("day", 0, "day of the puzzle, from 1 to 25")
+	year := fs.Int("year", 2024, "year of the event")
+	baseURL := fs.String("base-url", envOr("AOC_BASE_URL", input.DefaultBaseURL), "address of the website, e.g. of a stand-in server (env AOC_BASE_URL)")
+	cache := fs.String("cache", "", "cache directory (default: the per-user cache directory)")
+	interval := fs.Duration("interval", input.DefaultInterval, "minimum delay between two requests to the website")
+	out := fs.String("o", "", "file to write the input to, e.g. day07/input.txt (default: standard output)")
+	fs.Parse(args)
+
+	f, err := input.NewFetcher()
+	if err != nil {
+		return err
+	}
+	f.BaseURL = *baseURL
+	f.Interval = *interval
+	if *cache != "" {
+		f.CacheDir = *cache
+	}
+
+	// Stop waiting for the rate limit or the website on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	txt, err := f.Fetch(ctx, *year, *day)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err := fmt.Print(txt)
+		return err
+	}
+	return os.WriteFile(*out, []byte(txt), 0o644)
+}
+
+// envOr returns the value of an environment variable, or def if it is not set.
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
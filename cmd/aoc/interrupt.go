@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// errInterrupted is the cause of the context of a part stopped with Ctrl-C.
+var errInterrupted = errors.New("interrupted")
+
+// interrupter turns Ctrl-C into the cancellation of the part being solved, so that a run of several
+// parts reports the interrupted one and goes on with the others. A second Ctrl-C within a second quits.
+type interrupter struct {
+	mu     sync.Mutex
+	cancel context.CancelCauseFunc // cancel stops the part being solved, nil between parts.
+	last   time.Time               // last is the time of the last Ctrl-C.
+}
+
+// newInterrupter starts catching Ctrl-C. stop restores the default behavior.
+func newInterrupter() (it *interrupter, stop func()) {
+	it = &interrupter{}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	go func() {
+		for range ch {
+			it.mu.Lock()
+			if time.Since(it.last) < time.Second {
+				fmt.Fprintln(os.Stderr, "aoc: interrupted twice, quitting")
+				os.Exit(130)
+			}
+			it.last = time.Now()
+			if it.cancel != nil {
+				it.cancel(errInterrupted)
+			}
+			it.mu.Unlock()
+		}
+	}()
+	return it, func() { signal.Stop(ch) }
+}
+
+// part returns the context of a part, done on Ctrl-C or, if timeout is not zero, once the timeout expires.
+// done must be called when the part is over.
+func (it *interrupter) part(timeout time.Duration) (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stop := func() {}
+	if timeout > 0 {
+		ctx, stop = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("timed out after %v", timeout))
+	}
+
+	it.mu.Lock()
+	it.cancel = cancel
+	it.mu.Unlock()
+
+	return ctx, func() {
+		it.mu.Lock()
+		it.cancel = nil
+		it.mu.Unlock()
+		stop()
+		cancel(nil)
+	}
+}
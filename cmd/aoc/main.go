@@ -0,0 +1,77 @@
+// Command aoc gathers the tools around the Advent of Code 2024 solutions.
+//
+// Usage:
+//
+//	aoc [-v | -q] [-log-format text|json] <command> [flags]
+//
+// Run "aoc help" for the list of commands.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/cl3mcg/aoc2024/logging"
+)
+
+// command is a subcommand of aoc.
+type command struct {
+	name  string                    // name is the word typed after aoc.
+	usage string                    // usage is a one-line description of the command.
+	run   func(args []string) error // run executes the command with the arguments following its name.
+}
+
+// commands lists the subcommands of aoc, in the order shown by "aoc help".
+var commands = []command{
+	{"run", "solve a day, a part or all the days", runRun},
+	{"fetch", "download the input of a day into the cache", runFetch},
+	{"validate", "check that an input follows the format of a day, listing every problem", runValidate},
+	{"matrix", "solve every part against the inputs of every team member", runMatrix},
+	{"test", "check the solvers against the examples of the puzzle descriptions", runTest},
+	{"examples", "extract the examples of the puzzle descriptions into testdata", runExamples},
+	{"new", "create the directory of a new day from the templates", runNew},
+	{"vault", "encrypt the inputs into the vault (migrate) or write them back (decrypt)", runVault},
+	{"serve", "serve the inputs of this repository like the Advent of Code website", runServe},
+}
+
+// logger is the logger of the commands, set up from the flags given before the command name.
+// The solvers receive it through their context.
+var logger *slog.Logger
+
+func main() {
+	fs := flag.NewFlagSet("aoc", flag.ExitOnError)
+	fs.Usage = usage
+	logf := logging.NewFlags(fs)
+	fs.Parse(os.Args[1:])
+	logger = logf.Logger(os.Stderr)
+
+	args := fs.Args()
+	if len(args) == 0 || args[0] == "help" {
+		usage()
+		return
+	}
+
+	i := slices.IndexFunc(commands, func(c command) bool { return c.name == args[0] })
+	if i < 0 {
+		usage()
+		os.Exit(2)
+	}
+	if err := commands[i].run(args[1:]); err != nil {
+		logging.Fatal(logger, "command failed", "command", args[0], "err", err)
+	}
+}
+
+// usage prints the list of commands.
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: aoc [-v | -q] [-log-format text|json] <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `Run "aoc <command> -h" for the flags of a command.`)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+// runMatrix solves every part against the inputs of every team member, found in dir/dayXX/<name>.txt,
+// and prints the answers and timings as a matrix of parts by names.
+// A part that fails on some inputs but not on others is flagged, since it likely relies on a property
+// of one input that the puzzle does not guarantee. Only the registered solvers are run, not the go run
+// solutions of the day directories.
+func runMatrix(args []string) error {
+	fs := flag.NewFlagSet("matrix", flag.ExitOnError)
+	dir := fs.String("inputs", "inputs", "directory holding one dayXX directory of <name>.txt inputs per day")
+	day := fs.Int("day", 0, "day to solve (default: every registered day)")
+	timeout := fs.Duration("timeout", 0, "maximum time given to each part on each input (default: no limit)")
+	fs.Parse(args)
+
+	ps := runner.Parts()
+	if *day > 0 {
+		ps = runner.Lookup(*day, 0)
+	}
+
+	// Read the inputs of each day once, and collect the names across all days for the columns.
+	var names []string
+	inputs := make(map[int]map[string]string)
+	for _, p := range ps {
+		if _, ok := inputs[p.Day]; ok {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(*dir, fmt.Sprintf("day%02d", p.Day), "*.txt"))
+		if err != nil {
+			return err
+		}
+		inputs[p.Day] = make(map[string]string)
+		for _, f := range files {
+			d, err := os.ReadFile(f)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSuffix(filepath.Base(f), ".txt")
+			inputs[p.Day][name] = string(d)
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("matrix: no input found under %s/dayXX", *dir)
+	}
+	slices.Sort(names)
+
+	it, stop := newInterrupter()
+	defer stop()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\t%s\n", strings.Join(names, "\t"))
+
+	var flagged []string
+	failed := false
+	for _, p := range ps {
+		if len(inputs[p.Day]) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "day %02d part %d", p.Day, p.Part)
+
+		var ok, ko []string // ok and ko list the names the part solves and fails on.
+		for _, name := range names {
+			txt, found := inputs[p.Day][name]
+			if !found {
+				fmt.Fprint(tw, "\t-")
+				continue
+			}
+			ctx, done := it.part(*timeout)
+			o := runner.Run(logging.NewContext(ctx, logger.With("day", p.Day, "part", p.Part, "input", name)), p, txt)
+			done()
+			switch {
+			case o.Panicked:
+				fmt.Fprint(tw, "\tPANIC")
+				ko = append(ko, fmt.Sprintf("%s (%v)", name, o.Err))
+			case o.Interrupted:
+				fmt.Fprint(tw, "\tSTOPPED")
+				ko = append(ko, fmt.Sprintf("%s (%v)", name, o.Err))
+			case o.Err != nil:
+				fmt.Fprint(tw, "\tERROR")
+				ko = append(ko, fmt.Sprintf("%s (%v)", name, o.Err))
+			default:
+				fmt.Fprintf(tw, "\t%v (%v)", o.Answer, o.Elapsed.Round(time.Microsecond))
+				ok = append(ok, name)
+			}
+		}
+		fmt.Fprintln(tw)
+
+		if len(ko) > 0 {
+			failed = true
+		}
+		if len(ko) > 0 && len(ok) > 0 {
+			flagged = append(flagged, fmt.Sprintf("day %02d part %d solves %s but fails on %s",
+				p.Day, p.Part, strings.Join(ok, ", "), strings.Join(ko, ", ")))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// The inconsistent parts are listed after the matrix, with the error of each failing input.
+	if len(flagged) > 0 {
+		fmt.Println()
+		fmt.Println("Solvers depending on their input:")
+		for _, f := range flagged {
+			fmt.Println("  " + f)
+		}
+	}
+
+	if failed {
+		return errors.New("matrix: some parts failed on some inputs")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// templates holds the files written by "aoc new" for a new day.
+//
+//go:embed templates
+var templates embed.FS
+
+// daysMarker is the line of cmd/aoc/days.go before which "aoc new" inserts the import of a new day.
+const daysMarker = "// aoc new: end of days"
+
+// scaffold is the data given to the templates.
+type scaffold struct {
+	Day  int    // Day is the number of the day, e.g. 7.
+	Pkg  string // Pkg is the name of the day package, e.g. "day07".
+	Part int    // Part is the part of the NN_N directory being written, 1 or 2.
+}
+
+// runNew creates the directory of a new day with the repository layout, and registers it with the runner and the README.
+func runNew(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to create, from 1 to 25")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	fs.Parse(args)
+
+	if *day < 1 || *day > 25 {
+		return errors.New("new: -day must be between 1 and 25")
+	}
+	s := scaffold{Day: *day, Pkg: fmt.Sprintf("day%02d", *day)}
+	dir := filepath.Join(*root, s.Pkg)
+
+	// Never overwrite a day that already exists, even partially.
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("new: %s already exists", dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Check the files to update before writing anything, so that a wrong root leaves no half-created day.
+	daysPath := filepath.Join(*root, "cmd", "aoc", "days.go")
+	days, err := os.ReadFile(daysPath)
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(days, []byte(daysMarker)) {
+		return fmt.Errorf("new: %s has no %q line", daysPath, daysMarker)
+	}
+	readmePath := filepath.Join(*root, "README.md")
+	readme, err := os.ReadFile(readmePath)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		path, tmpl string
+		part       int
+	}{
+		{"solve.go", "solve.go.tmpl", 0},
+		{"solve_test.go", "solve_test.go.tmpl", 0},
+		{filepath.Join("01_1", "main.go"), "main.go.tmpl", 1},
+		{filepath.Join("01_1", "README.md"), "README.md.tmpl", 1},
+		{filepath.Join("02_1", "main.go"), "main.go.tmpl", 2},
+		{filepath.Join("02_1", "README.md"), "README.md.tmpl", 2},
+		{"input.txt", "", 0},
+	}
+	for _, f := range files {
+		s.Part = f.part
+		b, err := render(f.tmpl, s)
+		if err != nil {
+			return err
+		}
+		p := filepath.Join(dir, f.path)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, b, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("created", p)
+	}
+
+	// Import the new day so that its solvers are registered with the runner.
+	imp := fmt.Sprintf("_ \"github.com/cl3mcg/aoc2024/%s\"\n\t", s.Pkg)
+	days = bytes.Replace(days, []byte(daysMarker), []byte(imp+daysMarker), 1)
+	if err := os.WriteFile(daysPath, days, 0o644); err != nil {
+		return err
+	}
+	fmt.Println("updated", daysPath)
+
+	// Add the day to the recap table, after its last row.
+	if updated, ok := addRecapRow(string(readme), *day); ok {
+		if err := os.WriteFile(readmePath, []byte(updated), 0o644); err != nil {
+			return err
+		}
+		fmt.Println("updated", readmePath)
+	}
+	return nil
+}
+
+// render executes an embedded template with the scaffold data. Go files are formatted with gofmt.
+// An empty template name yields an empty file.
+func render(name string, s scaffold) ([]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+	t, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, s); err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return b.Bytes(), nil
+	}
+	return format.Source(b.Bytes())
+}
+
+// addRecapRow inserts the row of a day, with both parts pending, after the last row of the recap table of the README.
+// It reports false if the README has no recap table or already lists the day.
+func addRecapRow(readme string, day int) (string, bool) {
+	lines := strings.Split(readme, "\n")
+	last := -1
+	for i, l := range lines {
+		if !strings.HasPrefix(l, "| ") {
+			continue
+		}
+		if strings.HasPrefix(l, fmt.Sprintf("| %02d ", day)) {
+			return readme, false
+		}
+		last = i
+	}
+	if last < 0 {
+		return readme, false
+	}
+	row := fmt.Sprintf("| %02d  \t|    ⏳    \t|    ⏳    \t|", day)
+	lines = append(lines[:last+1], append([]string{row}, lines[last+1:]...)...)
+	return strings.Join(lines, "\n"), true
+}
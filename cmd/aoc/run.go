@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cl3mcg/aoc2024/input"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/progress"
+	"github.com/cl3mcg/aoc2024/puzzle"
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+// runRun solves one day, one part or every registered part, reading the inputs from the day directories.
+func runRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to solve")
+	part := fs.Int("part", 0, "part to solve, 1 or 2 (default: both)")
+	all := fs.Bool("all", false, "solve every registered day")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	in := fs.String("input", "", "input file (default: dayXX/input.txt under the root, or the vault)")
+	timeout := fs.Duration("timeout", 0, "maximum time given to each part, e.g. 10s (default: no limit)")
+	fs.Parse(args)
+
+	var ps []runner.Part
+	switch {
+	case *all:
+		ps = runner.Parts()
+	case *day > 0:
+		ps = runner.Lookup(*day, *part)
+	default:
+		return errors.New("run: -day or -all is required")
+	}
+	if len(ps) == 0 {
+		return fmt.Errorf("run: no solver registered for day %d", *day)
+	}
+
+	// Ctrl-C stops the part being solved only, the next parts are still solved.
+	it, stop := newInterrupter()
+	defer stop()
+
+	failed := false
+	// inputs are read once per day, since decrypting from the vault is slow on purpose. A day whose input
+	// cannot be read fails all its parts, and the next days are still solved.
+	inputs := make(map[int]dayInput)
+	for _, p := range ps {
+		di, ok := inputs[p.Day]
+		if !ok {
+			di.txt, di.err = readInput(*root, *in, p.Day)
+			inputs[p.Day] = di
+		}
+		if di.err != nil {
+			failed = true
+			fmt.Printf("day %02d part %d: error: %v\n", p.Day, p.Part, di.err)
+			continue
+		}
+		name := *in
+		if name == "" {
+			name = filepath.Join(*root, fmt.Sprintf("day%02d", p.Day), "input.txt")
+		}
+
+		// The progress reported by the solver goes to the standard error, away from the answers.
+		ctx, done := it.part(*timeout)
+		pr := progress.New()
+		stop := progress.Show(os.Stderr, logger, pr, fmt.Sprintf("day %02d part %d", p.Day, p.Part))
+		o := runner.Run(solverContext(progress.NewContext(ctx, pr), p), p, di.txt)
+		stop()
+		cause := context.Cause(ctx)
+		done()
+		if o.Interrupted {
+			failed = true
+			fmt.Printf("day %02d part %d: %v: %v (%v)\n", p.Day, p.Part, cause, o.Err, o.Elapsed)
+			continue
+		}
+		if o.Err != nil {
+			failed = true
+			fmt.Printf("day %02d part %d: error: %v (%v)\n", p.Day, p.Part, puzzle.WithFile(o.Err, name), o.Elapsed)
+			puzzle.Excerpt(os.Stdout, o.Err)
+			continue
+		}
+		fmt.Printf("day %02d part %d: %v (%v)\n", p.Day, p.Part, o.Answer, o.Elapsed)
+	}
+
+	if failed {
+		return errors.New("run: some parts failed")
+	}
+	return nil
+}
+
+// dayInput is the input of a day, or the error met while reading it.
+type dayInput struct {
+	txt string
+	err error
+}
+
+// readInput reads the given input file or, if there is none, the input of the day from the repository,
+// decrypting it from the vault when the plain input.txt is absent.
+func readInput(root, path string, day int) (string, error) {
+	if path == "" {
+		return input.Read(root, day)
+	}
+	d, err := os.ReadFile(path)
+	return string(d), err
+}
+
+// solverContext returns a copy of ctx carrying the logger of a part, tagged with the day and the part.
+func solverContext(ctx context.Context, p runner.Part) context.Context {
+	return logging.NewContext(ctx, logger.With("day", p.Day, "part", p.Part))
+}
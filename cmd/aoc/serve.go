@@ -0,0 +1,20 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/cl3mcg/aoc2024/input"
+)
+
+// runServe starts a stand-in for the Advent of Code website serving the inputs of this repository,
+// to try "aoc fetch -base-url" without reaching the real website.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:8024", "address to listen on")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	fs.Parse(args)
+
+	logger.Info("serving the inputs", "root", *root, "url", "http://"+*addr)
+	return http.ListenAndServe(*addr, input.StandInHandler(*root))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+// runValidate checks an input against the format of a day and reports every problem at once,
+// before any solver gets a chance to crash on it.
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	day := fs.Int("day", 0, "day whose format the input must follow")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aoc validate -day N [file]")
+		fmt.Fprintln(fs.Output(), "The file defaults to dayXX/input.txt under the root, or the vault.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *day == 0 || fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	validate, ok := runner.LookupValidator(*day)
+	if !ok {
+		return fmt.Errorf("validate: no validator registered for day %d", *day)
+	}
+
+	path := fs.Arg(0)
+	txt, err := readInput(*root, path, *day)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = filepath.Join(*root, fmt.Sprintf("day%02d", *day), "input.txt")
+	}
+
+	errs := validate(txt)
+	for _, err := range errs {
+		puzzle.Render(os.Stdout, puzzle.WithFile(err, path))
+	}
+	switch len(errs) {
+	case 0:
+	case 1:
+		return fmt.Errorf("validate: %s has 1 problem for day %d", path, *day)
+	default:
+		return fmt.Errorf("validate: %s has %d problems for day %d", path, len(errs), *day)
+	}
+	fmt.Printf("%s: valid input for day %d\n", path, *day)
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cl3mcg/aoc2024/input"
+)
+
+// runVault moves the plain inputs of the repository into the vault ("aoc vault migrate"),
+// or writes them back from the vault ("aoc vault decrypt"), e.g. after a fresh clone.
+// The passphrase is taken from AOC_VAULT_PASSPHRASE or from the passphrase file, see input.Passphrase.
+func runVault(args []string) error {
+	if len(args) == 0 || (args[0] != "migrate" && args[0] != "decrypt") {
+		return errors.New("vault: expected migrate or decrypt")
+	}
+	fs := flag.NewFlagSet("vault "+args[0], flag.ExitOnError)
+	day := fs.Int("day", 0, "day to process (default: every day)")
+	root := fs.String("root", ".", "root of the repository, holding the dayXX directories")
+	remove := fs.Bool("remove", false, "with migrate, delete the plain input.txt once it is in the vault")
+	fs.Parse(args[1:])
+
+	pass, err := input.Passphrase()
+	if err != nil {
+		return err
+	}
+
+	days := []int{*day}
+	if *day == 0 {
+		days = days[:0]
+		for d := 1; d <= 25; d++ {
+			if _, err := os.Stat(filepath.Join(*root, fmt.Sprintf("day%02d", d))); err == nil {
+				days = append(days, d)
+			}
+		}
+	}
+
+	for _, d := range days {
+		name := fmt.Sprintf("day%02d", d)
+		plain := filepath.Join(*root, name, "input.txt")
+		vault := input.VaultPath(*root, d)
+
+		if args[0] == "decrypt" {
+			sealed, err := os.ReadFile(vault)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			txt, err := input.Open(sealed, pass, name)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(plain, txt, 0o644); err != nil {
+				return err
+			}
+			fmt.Println("decrypted", plain)
+			continue
+		}
+
+		txt, err := os.ReadFile(plain)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		sealed, err := input.Seal(txt, pass, name)
+		if err != nil {
+			return err
+		}
+		// Check that the vault file opens before the plain input may be removed.
+		if back, err := input.Open(sealed, pass, name); err != nil || string(back) != string(txt) {
+			return fmt.Errorf("vault: %s does not decrypt back to its input", vault)
+		}
+		if err := os.WriteFile(vault, sealed, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("encrypted", plain, "into", vault)
+		if *remove {
+			if err := os.Remove(plain); err != nil {
+				return err
+			}
+		}
+	}
+
+	if args[0] == "migrate" {
+		fmt.Println(`Add "/day*/input.txt" to .gitignore and run "git rm --cached day*/input.txt" to stop publishing the plain inputs.`)
+	}
+	return nil
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"os"
-	"slices"
-	"strconv"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day01"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the puzzle input from a file at the specified path.
@@ -27,70 +29,64 @@ func retrievePuzzleInput(p string) (string, error) {
 }
 
 func main() {
-	// Read the puzzle input from the file "input.txt".
-	txt, err := retrievePuzzleInput("../input.txt")
-	if err != nil {
-		// Log a fatal error and terminate the program if the input file cannot be read.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
-	}
-
-	// Trim any leading or trailing whitespace characters from the input.
-	txt = strings.TrimSpace(txt)
-
-	// Split the input string into lines, each representing a pair of numbers.
-	l := strings.Split(txt, "\n")
-
-	// Initialize slices to store the left and right integers from each line.
-	var cl []int // cl will hold the left values.
-	var cr []int // cr will hold the right values.
-
-	// Iterate over each line to process the pairs of numbers.
-	for i, v := range l {
-		// Split the current line into two parts: left and right, using a single space as a delimiter.
-		codes := strings.SplitN(v, " ", 2)
-
-		// Trim spaces from the left and right parts to ensure clean inputs.
-		ls := strings.TrimSpace(codes[0]) // Left side as string.
-		rs := strings.TrimSpace(codes[1]) // Right side as string.
-
-		// Convert the left string to an integer.
-		li, err := strconv.Atoi(ls)
+	// The path of the puzzle input can be changed, e.g. to run against a generated input.
+	input := flag.String("input", "../input.txt", "path of the puzzle input")
+	stream := flag.Bool("stream", false, "read the input line by line in bounded memory, for inputs too large to load at once")
+	budget := flag.Int("budget", day01.DefaultBudget, "with -stream, number of location IDs held in memory before spilling to disk")
+	report := flag.String("report", "", "write an audit report instead of the answer: pairs, contributions or stats")
+	format := flag.String("format", "csv", "with -report, output format: csv or json")
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
+	// In streaming mode, the input file is never loaded as a whole.
+	if *stream {
+		f, err := os.Open(*input)
 		if err != nil {
-			// Log a fatal error if conversion fails, including contextual information for debugging.
-			log.Fatalf("Error converting the input to int on the left side. Index %v, Line Value %v, Left Value %v, Error: %v\n", i, v, ls, err)
+			logging.Fatal(logger, "Error opening the puzzle input", "err", err)
+		}
+		defer f.Close()
+
+		d, _, err := day01.Stream(f, day01.StreamOptions{Budget: *budget})
+		var pe *puzzle.ParseError
+		if errors.As(err, &pe) {
+			// A malformed line is shown with a caret under the bad token, as without -stream.
+			puzzle.Render(os.Stderr, puzzle.WithFile(err, *input))
+			os.Exit(1)
 		}
-
-		// Convert the right string to an integer.
-		ri, err := strconv.Atoi(rs)
 		if err != nil {
-			// Log a fatal error if conversion fails, including contextual information for debugging.
-			log.Fatalf("Error converting the input to int on the right side. Index %v, Line Value %v, Right Value %v, Error: %v\n", i, v, rs, err)
+			logging.Fatal(logger, "Error processing the puzzle input", "err", err)
 		}
-
-		// Append the converted integers to their respective slices.
-		cl = append(cl, li) // Left integer added to cl.
-		cr = append(cr, ri) // Right integer added to cr.
+		fmt.Println("The result 'r' should be: ", d)
+		return
 	}
 
-	// Sort both slices in ascending order to prepare for the next step of comparison.
-	slices.Sort(cl)
-	slices.Sort(cr)
+	// Read the puzzle input from the file "input.txt".
+	txt, err := retrievePuzzleInput(*input)
+	if err != nil {
+		// Log a fatal error and terminate the program if the input file cannot be read.
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
+	}
 
-	// Initialize a variable to accumulate the total difference between corresponding elements.
-	var r int
+	// Parse the left and right lists of location IDs.
+	cl, cr, err := day01.ParseLists(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, *input))
+		os.Exit(1)
+	}
 
-	// Iterate over the sorted left slice and calculate the absolute difference with the corresponding right slice.
-	for i, v := range cl {
-		// Calculate the difference between the current left and right values.
-		d := v - cr[i]
-		if d < 0 {
-			// If the difference is negative, convert it to positive (absolute value).
-			d = -d
+	// If a report is requested, write it instead of the answer.
+	if *report != "" {
+		if err := day01.WriteReport(os.Stdout, *report, *format, cl, cr); err != nil {
+			logging.Fatal(logger, "Error writing the report", "err", err)
 		}
-		// Accumulate the difference.
-		r += d
+		return
 	}
 
+	// Pair up the sorted lists and add up the distances between the paired values.
+	r := day01.TotalDistance(cl, cr)
+
 	// Print the final result, which is the sum of all absolute differences.
 	fmt.Println("The result 'r' should be: ", r)
 }
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"os"
-	"slices"
-	"strconv"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day01"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the puzzle input from a file at the specified path.
@@ -26,87 +28,65 @@ func retrievePuzzleInput(p string) (string, error) {
 	return string(d), nil
 }
 
-// countOccurrences counts how many times a specific value appears in a slice of integers.
-// It returns the count of occurrences.
-func countOccurrences(slice []int, value int) int {
-	var count int
-	// Iterate through the slice and increment the count for each match.
-	for _, v := range slice {
-		if v == value {
-			count++
+func main() {
+	// The path of the puzzle input can be changed, e.g. to run against a generated input.
+	input := flag.String("input", "../input.txt", "path of the puzzle input")
+	stream := flag.Bool("stream", false, "read the input line by line in bounded memory, for inputs too large to load at once")
+	budget := flag.Int("budget", day01.DefaultBudget, "with -stream, number of location IDs held in memory before spilling to disk")
+	report := flag.String("report", "", "write an audit report instead of the answer: pairs, contributions or stats")
+	format := flag.String("format", "csv", "with -report, output format: csv or json")
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
+	// In streaming mode, the input file is never loaded as a whole.
+	if *stream {
+		f, err := os.Open(*input)
+		if err != nil {
+			logging.Fatal(logger, "Error opening the puzzle input", "err", err)
+		}
+		defer f.Close()
+
+		_, s, err := day01.Stream(f, day01.StreamOptions{Budget: *budget})
+		var pe *puzzle.ParseError
+		if errors.As(err, &pe) {
+			// A malformed line is shown with a caret under the bad token, as without -stream.
+			puzzle.Render(os.Stderr, puzzle.WithFile(err, *input))
+			os.Exit(1)
 		}
+		if err != nil {
+			logging.Fatal(logger, "Error processing the puzzle input", "err", err)
+		}
+		fmt.Println("The result 'r' should be: ", s)
+		return
 	}
-	return count
-}
 
-func main() {
 	// Read the puzzle input from the file "input.txt".
-	txt, err := retrievePuzzleInput("../input.txt")
+	txt, err := retrievePuzzleInput(*input)
 	if err != nil {
 		// Log a fatal error and terminate the program if the input cannot be retrieved.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input.
-	txt = strings.TrimSpace(txt)
-
-	// Split the input string into lines, each representing a pair of numbers.
-	l := strings.Split(txt, "\n")
-
-	// Initialize slices to store the left and right integers from each line.
-	var cl []int // cl will hold the left values.
-	var cr []int // cr will hold the right values.
-
-	// Process each line to extract and convert the pair of numbers.
-	for i, v := range l {
-		// Split the current line into two parts: left and right, using a single space as a delimiter.
-		codes := strings.SplitN(v, " ", 2)
-
-		// Trim spaces from the left and right parts to ensure clean input.
-		ls := strings.TrimSpace(codes[0]) // Left side as string.
-		rs := strings.TrimSpace(codes[1]) // Right side as string.
-
-		// Convert the left string to an integer.
-		li, err := strconv.Atoi(ls)
-		if err != nil {
-			// Log a fatal error if conversion fails, providing context for debugging.
-			log.Fatalf("Error converting the input to int on the left side. Index %v, Line Value %v, Left Value %v, Error: %v\n", i, v, ls, err)
-		}
-
-		// Convert the right string to an integer.
-		ri, err := strconv.Atoi(rs)
-		if err != nil {
-			// Log a fatal error if conversion fails, providing context for debugging.
-			log.Fatalf("Error converting the input to int on the right side. Index %v, Line Value %v, Right Value %v, Error: %v\n", i, v, rs, err)
-		}
-
-		// Append the converted integers to their respective slices.
-		cl = append(cl, li) // Left integer added to cl.
-		cr = append(cr, ri) // Right integer added to cr.
+	// Parse the left and right lists of location IDs.
+	cl, cr, err := day01.ParseLists(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, *input))
+		os.Exit(1)
 	}
 
-	// Sort both slices in ascending order for consistent comparison.
-	slices.Sort(cl)
-	slices.Sort(cr)
-
-	// Initialize a variable to accumulate the total weighted sum of matches.
-	var r int
-
-	// Iterate over the sorted left slice.
-	for _, v := range cl {
-		// Check if the current left value exists in the right slice.
-		if !slices.Contains(cr, v) {
-			// If not, skip this value.
-			continue
+	// If a report is requested, write it instead of the answer.
+	if *report != "" {
+		if err := day01.WriteReport(os.Stdout, *report, *format, cl, cr); err != nil {
+			logging.Fatal(logger, "Error writing the report", "err", err)
 		}
-
-		// Count how many times the current value appears in the right slice.
-		t := countOccurrences(cr, v)
-
-		// Accumulate the product of the value and its count in the result.
-		r += t * v
+		return
 	}
 
+	// Compute the similarity score from a frequency map of the right list.
+	r := day01.SimilarityScore(cl, cr)
+
 	// Print the final result, which represents the weighted sum of matching values.
 	fmt.Println("The result 'r' should be: ", r)
 }
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+
+	"github.com/cl3mcg/aoc2024/logging"
+)
+
+// main writes a random Day 1 input with the requested number of lines, in the same format as the puzzle input
+// (two location IDs separated by three spaces). It is used to measure the solutions on inputs much larger than
+// input.txt, e.g.:
+//
+//	go run . -lines 1000000 -o /tmp/day01.txt && (cd ../02_1 && time go run . -input /tmp/day01.txt)
+func main() {
+	lines := flag.Int("lines", 1000000, "number of lines to generate")
+	maxID := flag.Int("max", 100000, "exclusive upper bound of the location IDs, lower values create more matches")
+	seed := flag.Uint64("seed", 1, "seed of the random generator")
+	out := flag.String("o", "", "file to write the input to (default: standard output)")
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
+	rng := rand.New(rand.NewPCG(*seed, *seed))
+
+	// Write the input to the requested destination through a buffer, as it can be large.
+	f := os.Stdout
+	if *out != "" {
+		var err error
+		f, err = os.Create(*out)
+		if err != nil {
+			logging.Fatal(logger, "Error creating the output file", "err", err)
+		}
+		defer f.Close()
+	}
+	w := bufio.NewWriter(f)
+
+	for range *lines {
+		fmt.Fprintf(w, "%d   %d\n", rng.IntN(*maxID), rng.IntN(*maxID))
+	}
+
+	if err := w.Flush(); err != nil {
+		logging.Fatal(logger, "Error writing the input", "err", err)
+	}
+}
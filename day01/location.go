@@ -0,0 +1,52 @@
+// Package day01 holds the logic shared by the solutions of Day 1 (Historian Hysteria).
+package day01
+
+import "slices"
+
+// TotalDistance pairs up the smallest left value with the smallest right value, the second smallest
+// with the second smallest, and so on, and returns the sum of the distances between the paired values.
+// The input slices are not modified.
+//
+// left: The location IDs of the left list.
+// right: The location IDs of the right list, with the same length as left.
+// Returns: The total distance between the two lists.
+func TotalDistance(left, right []int) int {
+	// Sort copies of both slices so that the caller's order is preserved.
+	cl := slices.Clone(left)
+	cr := slices.Clone(right)
+	slices.Sort(cl)
+	slices.Sort(cr)
+
+	// Accumulate the absolute difference between the paired values.
+	var r int
+	for i, v := range cl {
+		d := v - cr[i]
+		if d < 0 {
+			d = -d
+		}
+		r += d
+	}
+	return r
+}
+
+// SimilarityScore adds up each value of the left list multiplied by the number of times it appears
+// in the right list. The right list is counted once into a frequency map, so the score is computed
+// in linear time and without sorting.
+//
+// left: The location IDs of the left list.
+// right: The location IDs of the right list.
+// Returns: The similarity score of the two lists.
+func SimilarityScore(left, right []int) int {
+	// Count how many times each value appears in the right list.
+	counts := make(map[int]int, len(right))
+	for _, v := range right {
+		counts[v]++
+	}
+
+	// Values missing from the right list have a count of zero and add nothing.
+	var r int
+	for _, v := range left {
+		r += v * counts[v]
+	}
+	return r
+}
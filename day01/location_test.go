@@ -0,0 +1,76 @@
+package day01
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// quadratic also runs the former quadratic similarity score on a million pairs, which takes minutes.
+var quadratic = flag.Bool("quadratic", false, "benchmark the quadratic similarity score on a million pairs too")
+
+// generate returns n random pairs of location IDs below 100000, like day01/gen does by default.
+func generate(n int) (left, right []int) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	left, right = make([]int, n), make([]int, n)
+	for i := range n {
+		left[i], right[i] = rng.IntN(100000), rng.IntN(100000)
+	}
+	return left, right
+}
+
+// similarityScoreQuadratic is the similarity score as 02_1 used to compute it: the right list is scanned
+// for every value of the left list, first to find it and then to count it.
+func similarityScoreQuadratic(left, right []int) int {
+	var r int
+	for _, v := range left {
+		if !slices.Contains(right, v) {
+			continue
+		}
+		r += v * countOccurrences(right, v)
+	}
+	return r
+}
+
+// countOccurrences counts how many times a specific value appears in a slice of integers.
+func countOccurrences(slice []int, value int) int {
+	var count int
+	for _, v := range slice {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSimilarityScoreMatchesQuadratic(t *testing.T) {
+	left, right := generate(5000)
+	if got, want := SimilarityScore(left, right), similarityScoreQuadratic(left, right); got != want {
+		t.Errorf("SimilarityScore = %d, want %d", got, want)
+	}
+}
+
+// BenchmarkSimilarityScore compares the frequency map with the former quadratic scan, on up to a million
+// generated pairs. The quadratic scan only runs on a million pairs with -quadratic, e.g.:
+//
+//	go test -run XXX -bench SimilarityScore -quadratic -timeout 0 ./day01
+func BenchmarkSimilarityScore(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000, 1000000} {
+		left, right := generate(n)
+		b.Run(fmt.Sprintf("map/pairs=%d", n), func(b *testing.B) {
+			for range b.N {
+				SimilarityScore(left, right)
+			}
+		})
+		b.Run(fmt.Sprintf("quadratic/pairs=%d", n), func(b *testing.B) {
+			if n >= 1000000 && !*quadratic {
+				b.Skip("takes minutes, run with -quadratic")
+			}
+			for range b.N {
+				similarityScoreQuadratic(left, right)
+			}
+		})
+	}
+}
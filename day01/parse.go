@@ -0,0 +1,61 @@
+package day01
+
+import (
+	"errors"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// ErrColumns is reported when a line does not hold exactly two location IDs.
+var ErrColumns = errors.New("expected two location IDs")
+
+// ParseLists reads the two columns of location IDs of the puzzle input.
+// The columns can be separated by any run of spaces or tabs, blank lines are ignored,
+// and both LF and CRLF line endings are accepted.
+//
+// txt: The content of the puzzle input.
+// Returns: The left and right lists in input order, or a *puzzle.ParseError for the first invalid line.
+func ParseLists(txt string) (left, right []int, err error) {
+	for _, line := range puzzle.Lines(txt) {
+		l, r, ok, err := parseLine(line)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			// Skip blank lines.
+			continue
+		}
+		left = append(left, l)
+		right = append(right, r)
+	}
+	return left, right, nil
+}
+
+// parseLine parses a single line of the puzzle input.
+//
+// line: The line, possibly ending with a carriage return.
+// Returns: The left and right location IDs, false if the line is blank, or a *puzzle.ParseError
+// on the first extra or invalid location ID, or just after the line if an ID is missing.
+func parseLine(line puzzle.Line) (l, r int, ok bool, err error) {
+	// Fields splits on any run of white space and drops the trailing "\r" of CRLF endings.
+	f := line.Fields()
+	switch {
+	case len(f) == 0:
+		return 0, 0, false, nil
+	case len(f) < 2:
+		return 0, 0, false, line.Error(line.End(), ErrColumns)
+	case len(f) > 2:
+		return 0, 0, false, line.Error(f[2], ErrColumns)
+	}
+
+	// Convert the left and right strings to integers.
+	l, err = line.Atoi(f[0], "invalid location ID")
+	if err != nil {
+		return 0, 0, false, err
+	}
+	r, err = line.Atoi(f[1], "invalid location ID")
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return l, r, true, nil
+}
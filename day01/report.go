@@ -0,0 +1,191 @@
+package day01
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Pair is a left and a right location ID paired up by TotalDistance.
+type Pair struct {
+	Left     int `json:"left"`
+	Right    int `json:"right"`
+	Distance int `json:"distance"`
+}
+
+// Contribution is the part of the similarity score coming from one distinct value of the left list.
+type Contribution struct {
+	Value      int `json:"value"`
+	LeftCount  int `json:"left_count"`  // LeftCount is the number of times the value appears in the left list.
+	RightCount int `json:"right_count"` // RightCount is the number of times the value appears in the right list.
+	Score      int `json:"score"`       // Score is Value * LeftCount * RightCount.
+}
+
+// Stats summarizes the reconciliation between the two lists.
+type Stats struct {
+	Pairs          int     `json:"pairs"`
+	MinDistance    int     `json:"min_distance"`
+	MaxDistance    int     `json:"max_distance"`
+	MedianDistance float64 `json:"median_distance"`
+	DistinctLeft   int     `json:"distinct_left"`
+	DistinctRight  int     `json:"distinct_right"`
+	OnlyLeft       []int   `json:"only_left"`  // OnlyLeft lists the distinct values missing from the right list.
+	OnlyRight      []int   `json:"only_right"` // OnlyRight lists the distinct values missing from the left list.
+}
+
+// Pairs returns the full sorted pairing used for the total distance, smallest values first.
+//
+// left: The location IDs of the left list.
+// right: The location IDs of the right list, with the same length as left.
+// Returns: One Pair per line of the input.
+func Pairs(left, right []int) []Pair {
+	cl := slices.Clone(left)
+	cr := slices.Clone(right)
+	slices.Sort(cl)
+	slices.Sort(cr)
+
+	ps := make([]Pair, len(cl))
+	for i, v := range cl {
+		d := v - cr[i]
+		if d < 0 {
+			d = -d
+		}
+		ps[i] = Pair{Left: v, Right: cr[i], Distance: d}
+	}
+	return ps
+}
+
+// Contributions returns the similarity score contribution of every distinct value of the left list,
+// sorted by value. Values missing from the right list are included with a score of zero.
+func Contributions(left, right []int) []Contribution {
+	cl := counts(left)
+	cr := counts(right)
+
+	var cs []Contribution
+	for _, v := range slices.Sorted(maps.Keys(cl)) {
+		cs = append(cs, Contribution{Value: v, LeftCount: cl[v], RightCount: cr[v], Score: v * cl[v] * cr[v]})
+	}
+	return cs
+}
+
+// Summarize computes the summary statistics of the two lists.
+func Summarize(left, right []int) Stats {
+	ps := Pairs(left, right)
+	cl := counts(left)
+	cr := counts(right)
+
+	s := Stats{
+		Pairs:         len(ps),
+		DistinctLeft:  len(cl),
+		DistinctRight: len(cr),
+		OnlyLeft:      []int{},
+		OnlyRight:     []int{},
+	}
+
+	// The distances are sorted to get the extremes and the median.
+	if len(ps) > 0 {
+		ds := make([]int, len(ps))
+		for i, p := range ps {
+			ds[i] = p.Distance
+		}
+		slices.Sort(ds)
+		s.MinDistance = ds[0]
+		s.MaxDistance = ds[len(ds)-1]
+		if len(ds)%2 == 1 {
+			s.MedianDistance = float64(ds[len(ds)/2])
+		} else {
+			s.MedianDistance = float64(ds[len(ds)/2-1]+ds[len(ds)/2]) / 2
+		}
+	}
+
+	for _, v := range slices.Sorted(maps.Keys(cl)) {
+		if cr[v] == 0 {
+			s.OnlyLeft = append(s.OnlyLeft, v)
+		}
+	}
+	for _, v := range slices.Sorted(maps.Keys(cr)) {
+		if cl[v] == 0 {
+			s.OnlyRight = append(s.OnlyRight, v)
+		}
+	}
+	return s
+}
+
+// counts returns the number of occurrences of each value of a list.
+func counts(list []int) map[int]int {
+	c := make(map[int]int, len(list))
+	for _, v := range list {
+		c[v]++
+	}
+	return c
+}
+
+// WriteReport writes one of the audit reports of the two lists.
+//
+// w: The destination of the report.
+// kind: "pairs" for the sorted pairing, "contributions" for the similarity contributions, or "stats".
+// format: "csv" or "json". JSON reports are written as a single indented document.
+// left, right: The two lists of location IDs.
+// Returns: An error if the kind or the format is unknown, or if writing fails.
+func WriteReport(w io.Writer, kind, format string, left, right []int) error {
+	var v any
+	var rows [][]string
+
+	// Build both representations of the requested report.
+	switch kind {
+	case "pairs":
+		ps := Pairs(left, right)
+		v = ps
+		rows = append(rows, []string{"left", "right", "distance"})
+		for _, p := range ps {
+			rows = append(rows, itoas(p.Left, p.Right, p.Distance))
+		}
+	case "contributions":
+		cs := Contributions(left, right)
+		v = cs
+		rows = append(rows, []string{"value", "left_count", "right_count", "score"})
+		for _, c := range cs {
+			rows = append(rows, itoas(c.Value, c.LeftCount, c.RightCount, c.Score))
+		}
+	case "stats":
+		s := Summarize(left, right)
+		v = s
+		rows = [][]string{
+			{"stat", "value"},
+			{"pairs", strconv.Itoa(s.Pairs)},
+			{"min_distance", strconv.Itoa(s.MinDistance)},
+			{"max_distance", strconv.Itoa(s.MaxDistance)},
+			{"median_distance", strconv.FormatFloat(s.MedianDistance, 'f', -1, 64)},
+			{"distinct_left", strconv.Itoa(s.DistinctLeft)},
+			{"distinct_right", strconv.Itoa(s.DistinctRight)},
+			{"only_left", strings.Join(itoas(s.OnlyLeft...), " ")},
+			{"only_right", strings.Join(itoas(s.OnlyRight...), " ")},
+		}
+	default:
+		return fmt.Errorf("unknown report %q", kind)
+	}
+
+	switch format {
+	case "csv":
+		return csv.NewWriter(w).WriteAll(rows)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// itoas converts integers to their decimal representation.
+func itoas(vs ...int) []string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = strconv.Itoa(v)
+	}
+	return s
+}
@@ -0,0 +1,31 @@
+package day01
+
+import (
+	"context"
+
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+func init() {
+	runner.Register(1, 1, Part1)
+	runner.Register(1, 2, Part2)
+	runner.RegisterValidator(1, Validate)
+}
+
+// Part1 returns the total distance between the two lists of the puzzle input.
+func Part1(ctx context.Context, txt string) (int, error) {
+	l, r, err := ParseLists(txt)
+	if err != nil {
+		return 0, err
+	}
+	return TotalDistance(l, r), nil
+}
+
+// Part2 returns the similarity score of the two lists of the puzzle input.
+func Part2(ctx context.Context, txt string) (int, error) {
+	l, r, err := ParseLists(txt)
+	if err != nil {
+		return 0, err
+	}
+	return SimilarityScore(l, r), nil
+}
@@ -0,0 +1,411 @@
+package day01
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// StreamOptions configures Stream.
+type StreamOptions struct {
+	Budget int    // Budget is the number of location IDs held in memory before sorted runs are spilled to disk.
+	TmpDir string // TmpDir is the directory of the temporary run files, os.TempDir() if empty.
+}
+
+// DefaultBudget is the memory budget used when StreamOptions.Budget is not set: 4M IDs, about 32 MiB.
+const DefaultBudget = 4 << 20
+
+// maxFanIn is the maximum number of runs merged at once. Longer lists of runs are first merged into
+// longer runs, in several passes, so that the number of open files stays small whatever the budget.
+const maxFanIn = 64
+
+// Stream computes the total distance and the similarity score of a two-column input of any size.
+// The input is read line by line through a bufio.Scanner. Both lists are sorted: values are sorted in memory
+// while they fit in the budget, and otherwise sorted runs are written to temporary files and merged back
+// (external merge sort), which keeps the memory bounded. The sorted lists are then read twice: once paired
+// up for the total distance, and once joined on equal values for the similarity score.
+//
+// r: The reader of the puzzle input.
+// opts: The memory budget and the location of the temporary files.
+// Returns: The total distance, the similarity score, or an error if the input cannot be read or parsed.
+func Stream(r io.Reader, opts StreamOptions) (distance, similarity int, err error) {
+	if opts.Budget <= 0 {
+		opts.Budget = DefaultBudget
+	}
+
+	// Each side keeps its own buffer and its own runs on disk.
+	left := &sorter{budget: opts.Budget / 2, dir: opts.TmpDir}
+	right := &sorter{budget: opts.Budget / 2, dir: opts.TmpDir}
+	defer left.cleanup()
+	defer right.cleanup()
+
+	sc := bufio.NewScanner(r)
+	n := 0
+	for sc.Scan() {
+		n++
+		l, rv, ok, err := parseLine(puzzle.Line{Num: n, Text: sc.Text()})
+		if err != nil {
+			return 0, 0, err
+		}
+		if !ok {
+			continue
+		}
+		if err := left.add(l); err != nil {
+			return 0, 0, err
+		}
+		if err := right.add(rv); err != nil {
+			return 0, 0, err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, 0, err
+	}
+	if left.count != right.count {
+		return 0, 0, errors.New("the two lists do not have the same length")
+	}
+
+	li, ri, err := iters(left, right)
+	if err != nil {
+		return 0, 0, err
+	}
+	if distance, err = totalDistance(li, ri); err != nil {
+		return 0, 0, err
+	}
+	if li, ri, err = iters(left, right); err != nil {
+		return 0, 0, err
+	}
+	if similarity, err = similarityScore(li, ri); err != nil {
+		return 0, 0, err
+	}
+	return distance, similarity, nil
+}
+
+// iters returns new iterators over the sorted values of both sides.
+func iters(left, right *sorter) (li, ri iterator, err error) {
+	if li, err = left.iter(); err != nil {
+		return nil, nil, err
+	}
+	if ri, err = right.iter(); err != nil {
+		return nil, nil, err
+	}
+	return li, ri, nil
+}
+
+// totalDistance pairs up the values of both sorted lists in order and adds up their distances.
+func totalDistance(li, ri iterator) (int, error) {
+	var r int
+	for {
+		l, okL, err := li.next()
+		if err != nil {
+			return 0, err
+		}
+		rv, okR, err := ri.next()
+		if err != nil {
+			return 0, err
+		}
+		if !okL || !okR {
+			return r, nil
+		}
+		d := l - rv
+		if d < 0 {
+			d = -d
+		}
+		r += d
+	}
+}
+
+// similarityScore joins both sorted lists on equal values (merge-join): each value v found cl times on the left
+// and cr times on the right scores v*cl*cr. Only the current value of each side is held in memory.
+func similarityScore(li, ri iterator) (int, error) {
+	l, r := &groupIter{it: li}, &groupIter{it: ri}
+	if err := l.advance(); err != nil {
+		return 0, err
+	}
+	if err := r.advance(); err != nil {
+		return 0, err
+	}
+
+	var score int
+	for l.ok && r.ok {
+		switch {
+		case l.v < r.v:
+			if err := l.advance(); err != nil {
+				return 0, err
+			}
+		case l.v > r.v:
+			if err := r.advance(); err != nil {
+				return 0, err
+			}
+		default:
+			score += l.v * l.n * r.n
+			if err := l.advance(); err != nil {
+				return 0, err
+			}
+			if err := r.advance(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return score, nil
+}
+
+// groupIter reads a sorted iterator as groups of equal values.
+type groupIter struct {
+	it   iterator
+	v, n int  // v is the value of the current group and n the number of times it appears.
+	ok   bool // ok is false once all the values have been read.
+
+	next    int  // next is the first value of the following group, read ahead.
+	hasNext bool // hasNext reports whether next holds a value.
+	started bool // started is true once the first value has been read ahead.
+}
+
+// advance moves to the next group of equal values.
+func (g *groupIter) advance() error {
+	if !g.started {
+		g.started = true
+		v, ok, err := g.it.next()
+		if err != nil {
+			return err
+		}
+		g.next, g.hasNext = v, ok
+	}
+	if !g.hasNext {
+		g.ok = false
+		return nil
+	}
+
+	g.v, g.n, g.ok = g.next, 0, true
+	for g.hasNext && g.next == g.v {
+		g.n++
+		v, ok, err := g.it.next()
+		if err != nil {
+			return err
+		}
+		g.next, g.hasNext = v, ok
+	}
+	return nil
+}
+
+// sorter accumulates the values of one list and spills them as sorted runs when the budget is exceeded.
+type sorter struct {
+	budget int        // budget is the number of values kept in memory.
+	dir    string     // dir is the directory of the run files.
+	buf    []int      // buf holds the values not yet spilled.
+	runs   []string   // runs are the paths of the spilled files, each one sorted. They stay closed until merged.
+	open   []*os.File // open are the run files read by the last iterator.
+	count  int        // count is the total number of values added.
+}
+
+// add appends a value to the buffer and spills the buffer if it is full.
+func (s *sorter) add(v int) error {
+	s.buf = append(s.buf, v)
+	s.count++
+	if len(s.buf) >= max(s.budget, 1) {
+		return s.spill()
+	}
+	return nil
+}
+
+// spill sorts the buffer and writes it to a new run file.
+func (s *sorter) spill() error {
+	slices.Sort(s.buf)
+	name, err := s.writeRun(&sliceIter{s: s.buf})
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, name)
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// writeRun writes the values of it, in ascending order, to a new temporary file and returns its path.
+func (s *sorter) writeRun(it iterator) (string, error) {
+	f, err := os.CreateTemp(s.dir, "day01-run-*")
+	if err != nil {
+		return "", err
+	}
+	err = writeVarints(f, it)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// writeVarints writes every value of it to w as varints.
+func writeVarints(w io.Writer, it iterator) error {
+	bw := bufio.NewWriter(w)
+	var b [binary.MaxVarintLen64]byte
+	for {
+		v, ok, err := it.next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return bw.Flush()
+		}
+		if _, err := bw.Write(b[:binary.PutVarint(b[:], int64(v))]); err != nil {
+			return err
+		}
+	}
+}
+
+// iter returns an iterator over all the values in ascending order. It can be called again to read them
+// once more, which ends the previous iterator. Without any run on disk, the buffer is simply sorted in memory.
+func (s *sorter) iter() (iterator, error) {
+	s.closeOpen()
+	if len(s.runs) == 0 {
+		slices.Sort(s.buf)
+		return &sliceIter{s: s.buf}, nil
+	}
+
+	// Spill the remaining values so that every value is in a run.
+	if len(s.buf) > 0 {
+		if err := s.spill(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Merge the oldest runs into a longer one until few enough are left to be merged at once.
+	for len(s.runs) > maxFanIn {
+		m, err := s.merge(s.runs[:maxFanIn])
+		if err != nil {
+			return nil, err
+		}
+		name, err := s.writeRun(m)
+		s.closeOpen()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range s.runs[:maxFanIn] {
+			os.Remove(r)
+		}
+		s.runs = append(slices.Clone(s.runs[maxFanIn:]), name)
+	}
+	return s.merge(s.runs)
+}
+
+// merge opens the given runs and returns an iterator merging them.
+// The files are kept in s.open until the next call to closeOpen.
+func (s *sorter) merge(runs []string) (*mergeIter, error) {
+	m := &mergeIter{}
+	for _, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		s.open = append(s.open, f)
+		rr := &runReader{r: bufio.NewReader(f)}
+		ok, err := rr.advance()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m.h = append(m.h, rr)
+		}
+	}
+	heap.Init(&m.h)
+	return m, nil
+}
+
+// closeOpen closes the run files read by the last iterator.
+func (s *sorter) closeOpen() {
+	for _, f := range s.open {
+		f.Close()
+	}
+	s.open = nil
+}
+
+// cleanup closes and removes the run files.
+func (s *sorter) cleanup() {
+	s.closeOpen()
+	for _, name := range s.runs {
+		os.Remove(name)
+	}
+	s.runs = nil
+}
+
+// iterator yields values in ascending order. ok is false once all the values have been read.
+type iterator interface {
+	next() (v int, ok bool, err error)
+}
+
+// sliceIter iterates over a sorted slice.
+type sliceIter struct {
+	s []int
+	i int
+}
+
+func (it *sliceIter) next() (int, bool, error) {
+	if it.i >= len(it.s) {
+		return 0, false, nil
+	}
+	it.i++
+	return it.s[it.i-1], true, nil
+}
+
+// runReader reads the values of a run file, holding the current smallest value in cur.
+type runReader struct {
+	r   *bufio.Reader
+	cur int
+}
+
+// advance reads the next value of the run into cur. It returns false at the end of the run.
+func (rr *runReader) advance() (bool, error) {
+	v, err := binary.ReadVarint(rr.r)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	rr.cur = int(v)
+	return true, nil
+}
+
+// runHeap is a min-heap of runs ordered by their current value.
+type runHeap []*runReader
+
+func (h runHeap) Len() int           { return len(h) }
+func (h runHeap) Less(i, j int) bool { return h[i].cur < h[j].cur }
+func (h runHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *runHeap) Push(x any)        { *h = append(*h, x.(*runReader)) }
+func (h *runHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// mergeIter merges sorted runs by always taking the smallest current value.
+type mergeIter struct {
+	h runHeap
+}
+
+func (it *mergeIter) next() (int, bool, error) {
+	if len(it.h) == 0 {
+		return 0, false, nil
+	}
+	top := it.h[0]
+	v := top.cur
+	ok, err := top.advance()
+	if err != nil {
+		return 0, false, err
+	}
+	if ok {
+		heap.Fix(&it.h, 0)
+	} else {
+		heap.Pop(&it.h)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,65 @@
+package day01
+
+import (
+	"errors"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// randomInput returns n random pairs of location IDs below maxID, and the input holding them with
+// separators and line endings picked at random among spaces, tabs, LF and CRLF.
+func randomInput(rng *rand.Rand, n, maxID int) (left, right []int, txt string) {
+	seps := []string{"   ", "\t", " \t ", " "}
+	ends := []string{"\n", "\r\n"}
+	var b strings.Builder
+	for range n {
+		l, r := rng.IntN(maxID), rng.IntN(maxID)
+		left, right = append(left, l), append(right, r)
+		b.WriteString(strconv.Itoa(l) + seps[rng.IntN(len(seps))] + strconv.Itoa(r) + ends[rng.IntN(len(ends))])
+	}
+	// A blank line at the end is ignored.
+	b.WriteString("\r\n")
+	return left, right, b.String()
+}
+
+func TestStream(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2, 2))
+	// A budget of 1 to 3 keeps one ID per side in memory, so that every line spills a run on each side
+	// and the 5000-line input needs several merge passes of maxFanIn runs.
+	for _, budget := range []int{1, 2, 3, 100, 0} {
+		for _, n := range []int{0, 1, 2, maxFanIn + 1, 300, 5000} {
+			// Few distinct IDs make many repeated values for the similarity score.
+			left, right, txt := randomInput(rng, n, 50)
+			dir := t.TempDir()
+
+			distance, similarity, err := Stream(strings.NewReader(txt), StreamOptions{Budget: budget, TmpDir: dir})
+			if err != nil {
+				t.Fatalf("budget %d, %d lines: %v", budget, n, err)
+			}
+			if want := TotalDistance(left, right); distance != want {
+				t.Errorf("budget %d, %d lines: distance = %d, want %d", budget, n, distance, want)
+			}
+			if want := SimilarityScore(left, right); similarity != want {
+				t.Errorf("budget %d, %d lines: similarity = %d, want %d", budget, n, similarity, want)
+			}
+
+			// The run files are removed once the lists are merged.
+			if files, err := os.ReadDir(dir); err != nil || len(files) > 0 {
+				t.Errorf("budget %d, %d lines: %d run files left in the temporary directory (%v)", budget, n, len(files), err)
+			}
+		}
+	}
+}
+
+func TestStreamParseError(t *testing.T) {
+	_, _, err := Stream(strings.NewReader("3   4\r\n4\t12a4\n"), StreamOptions{Budget: 1, TmpDir: t.TempDir()})
+	var pe *puzzle.ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Token != "12a4" {
+		t.Errorf("Stream of a malformed input: err = %v, want a ParseError on 12a4 at line 2", err)
+	}
+}
@@ -0,0 +1,34 @@
+package day01
+
+import "github.com/cl3mcg/aoc2024/puzzle"
+
+// Validate checks the puzzle input against the format of Day 1: every non-blank line holds
+// two integer columns. Unlike ParseLists, it goes on after a problem and reports them all.
+//
+// txt: The content of the puzzle input.
+// Returns: A *puzzle.ParseError for each missing, extra or invalid location ID, or puzzle.ErrEmpty.
+func Validate(txt string) []error {
+	var errs []error
+	pairs := 0
+	for _, line := range puzzle.Lines(txt) {
+		f := line.Fields()
+		switch {
+		case len(f) == 0:
+			continue
+		case len(f) < 2:
+			errs = append(errs, line.Error(line.End(), ErrColumns))
+		case len(f) > 2:
+			errs = append(errs, line.Error(f[2], ErrColumns))
+		}
+		for _, w := range f[:min(len(f), 2)] {
+			if _, err := line.Atoi(w, "invalid location ID"); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		pairs++
+	}
+	if pairs == 0 {
+		errs = append(errs, puzzle.ErrEmpty)
+	}
+	return errs
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"log"
 	"os"
-	"strconv"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day02"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the puzzle input from a file at the specified path.
@@ -25,99 +27,59 @@ func retrievePuzzleInput(p string) (string, error) {
 	return string(d), nil
 }
 
-// level represents a collection of integer data from the input puzzle.
-type level struct {
-	data []int // Data is a slice of integers representing the current level's values.
-}
-
-// checkIncr checks if the data in the level is strictly increasing with each value
-// being greater than the previous one, and the difference between consecutive values is at most 3.
-func (l level) checkIncr() bool {
-	for i, v := range l.data {
-		// Skip the first value as there is no previous value to compare.
-		if i == 0 {
-			continue
-		}
-		// If the current value is greater than the previous and the difference is <= 3, continue checking.
-		if v > l.data[i-1] && v-l.data[i-1] <= 3 {
-			continue
-		} else {
-			// Return false if the sequence is not increasing or the difference is greater than 3.
-			return false
-		}
+func main() {
+	// The safety policy defaults to the puzzle rules and can be changed with flags or a JSON file.
+	pf := day02.NewPolicyFlags(flag.CommandLine, day02.PuzzlePolicy)
+	verdicts := flag.String("verdicts", "", "list the verdict of every report instead of the answer: text or jsonl")
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+	// Only the known formats are accepted, so that a typo like -verdicts json does not silently print text.
+	if *verdicts != "" && *verdicts != "text" && *verdicts != "jsonl" {
+		logging.Fatal(logger, "Unknown verdict format, expected text or jsonl", "verdicts", *verdicts)
 	}
-	// Return true if the entire sequence satisfies the conditions.
-	return true
-}
-
-// checkDecr checks if the data in the level is strictly decreasing with each value
-// being smaller than the previous one, and the difference between consecutive values is at most 3.
-func (l level) checkDecr() bool {
-	for i, v := range l.data {
-		// Skip the first value as there is no previous value to compare.
-		if i == 0 {
-			continue
-		}
-		// If the current value is smaller than the previous and the difference is <= 3, continue checking.
-		if v < l.data[i-1] && l.data[i-1]-v <= 3 {
-			continue
-		} else {
-			// Return false if the sequence is not decreasing or the difference is greater than 3.
-			return false
-		}
+	pol, err := pf.Policy()
+	if err != nil {
+		logging.Fatal(logger, "Error loading the safety policy", "err", err)
 	}
-	// Return true if the entire sequence satisfies the conditions.
-	return true
-}
 
-func main() {
 	// Read the puzzle input from the file "input.txt".
 	txt, err := retrievePuzzleInput("../input.txt")
 	if err != nil {
 		// Log a fatal error and terminate the program if the input file cannot be read.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input to clean it.
-	txt = strings.TrimSpace(txt)
-
-	// Split the input string into lines, with each line representing a group of numbers.
-	r := strings.Split(txt, "\n")
-
-	// Initialize the 'valid' counter with the number of lines in the input.
-	valid := len(r)
-
-	// Iterate over each line in the input.
-	for _, v := range r {
-		// Remove any carriage return characters that may be present.
-		v = strings.Replace(v, "\r", "", -1)
-
-		// Trim any extra whitespace from the line.
-		strings.TrimSpace(v)
-
-		// Split the line into individual string values (representing numbers).
-		ds := strings.Split(v, " ")
+	// Parse the reports, one per line.
+	reports, err := day02.ParseReports(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, "../input.txt"))
+		os.Exit(1)
+	}
 
-		// Create a new 'level' instance to store the parsed data.
-		l := level{}
+	valid := 0 // Start with a count of safe reports
 
-		// Convert each string value to an integer and append it to the 'level' data.
-		for _, w := range ds {
-			di, err := strconv.Atoi(w)
-			if err != nil {
-				// Log an error and terminate the program if a string cannot be converted to an integer.
-				log.Fatalf("Error converting %s to int: %v", w, err)
+	// Iterate over each report.
+	for n, l := range reports {
+		// In verdict mode, explain the safety of each report instead of counting the safe ones.
+		if *verdicts != "" {
+			if err := day02.WriteVerdict(os.Stdout, pol.Judge(n+1, l), *verdicts == "jsonl"); err != nil {
+				logging.Fatal(logger, "Error writing the verdict", "err", err)
 			}
-			l.data = append(l.data, di)
+			continue
 		}
 
-		// Check if the data in the level is neither increasing nor decreasing in a valid manner.
-		// If both checks fail, decrement the 'valid' counter.
-		if !l.checkIncr() && !l.checkDecr() {
-			valid--
+		// Check if the report is safe according to the policy.
+		if pol.Safe(l) {
+			valid++
 		}
 	}
 
+	if *verdicts != "" {
+		return
+	}
+
 	// Print the final count of valid levels.
 	fmt.Println("The result 'valid' should be: ", valid)
 }
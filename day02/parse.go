@@ -0,0 +1,30 @@
+package day02
+
+import "github.com/cl3mcg/aoc2024/puzzle"
+
+// ParseReports converts the puzzle input into reports, one per non-blank line.
+// Levels can be separated by any run of white space, and CRLF line endings are accepted.
+// Every report gets its own backing array, so the reports never share memory.
+//
+// txt: The content of the puzzle input.
+// Returns: The levels of each report, or a *puzzle.ParseError on the first invalid level.
+func ParseReports(txt string) ([][]int, error) {
+	var reports [][]int
+	for _, line := range puzzle.Lines(txt) {
+		f := line.Fields()
+		if len(f) == 0 {
+			continue
+		}
+
+		levels := make([]int, len(f))
+		for j, w := range f {
+			d, err := line.Atoi(w, "invalid level")
+			if err != nil {
+				return nil, err
+			}
+			levels[j] = d
+		}
+		reports = append(reports, levels)
+	}
+	return reports, nil
+}
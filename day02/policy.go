@@ -0,0 +1,97 @@
+package day02
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+)
+
+// Policy describes when a report is considered safe.
+type Policy struct {
+	MinStep             int  `json:"min_step"`             // MinStep is the smallest difference allowed between two adjacent levels.
+	MaxStep             int  `json:"max_step"`             // MaxStep is the largest difference allowed between two adjacent levels.
+	AllowEqual          bool `json:"allow_equal"`          // AllowEqual allows two adjacent levels to be equal, whatever MinStep.
+	Removals            int  `json:"removals"`             // Removals is the number of levels the Problem Dampener may remove.
+	ConsistentDirection bool `json:"consistent_direction"` // ConsistentDirection requires all the levels to go in the same direction.
+}
+
+// PuzzlePolicy is the policy of the puzzle: strictly monotonic reports with steps of one to three, without dampener.
+var PuzzlePolicy = Policy{MinStep: 1, MaxStep: 3, ConsistentDirection: true}
+
+// step reports whether going from level a to level b follows the policy in the given direction:
+// +1 for increasing reports, -1 for decreasing reports, or 0 if any direction is allowed.
+func (p Policy) step(a, b, dir int) bool {
+	d := b - a
+	if d == 0 && p.AllowEqual {
+		return true
+	}
+	switch dir {
+	case 0:
+		d = max(d, -d)
+	default:
+		d *= dir
+	}
+	return d != 0 && d >= p.MinStep && d <= p.MaxStep
+}
+
+// LoadPolicy reads a policy from a JSON file such as {"max_step": 5, "removals": 2}.
+// The fields missing from the file keep their value from base.
+func LoadPolicy(path string, base Policy) (Policy, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, err
+	}
+	if err := json.Unmarshal(d, &base); err != nil {
+		return Policy{}, err
+	}
+	return base, nil
+}
+
+// PolicyFlags binds a policy to command-line flags.
+// The policy is built from a base, then the optional -policy file, then the flags explicitly set.
+type PolicyFlags struct {
+	fs   *flag.FlagSet
+	base Policy
+	file string
+	set  Policy
+}
+
+// NewPolicyFlags registers the policy flags on fs, using base for the default values.
+func NewPolicyFlags(fs *flag.FlagSet, base Policy) *PolicyFlags {
+	pf := &PolicyFlags{fs: fs, base: base, set: base}
+	fs.StringVar(&pf.file, "policy", "", "JSON file holding the safety policy, overridden by the other policy flags")
+	fs.IntVar(&pf.set.MinStep, "min-step", base.MinStep, "smallest difference allowed between two adjacent levels")
+	fs.IntVar(&pf.set.MaxStep, "max-step", base.MaxStep, "largest difference allowed between two adjacent levels")
+	fs.BoolVar(&pf.set.AllowEqual, "allow-equal", base.AllowEqual, "allow two adjacent levels to be equal")
+	fs.IntVar(&pf.set.Removals, "removals", base.Removals, "number of levels the Problem Dampener may remove")
+	fs.BoolVar(&pf.set.ConsistentDirection, "consistent", base.ConsistentDirection, "require all the levels to go in the same direction")
+	return pf
+}
+
+// Policy returns the policy resulting from the parsed flags.
+func (pf *PolicyFlags) Policy() (Policy, error) {
+	p := pf.base
+	if pf.file != "" {
+		var err error
+		if p, err = LoadPolicy(pf.file, p); err != nil {
+			return Policy{}, err
+		}
+	}
+
+	// Only the flags given on the command line override the file.
+	pf.fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "min-step":
+			p.MinStep = pf.set.MinStep
+		case "max-step":
+			p.MaxStep = pf.set.MaxStep
+		case "allow-equal":
+			p.AllowEqual = pf.set.AllowEqual
+		case "removals":
+			p.Removals = pf.set.Removals
+		case "consistent":
+			p.ConsistentDirection = pf.set.ConsistentDirection
+		}
+	})
+	return p, nil
+}
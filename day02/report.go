@@ -0,0 +1,179 @@
+// Package day02 holds the logic shared by the solutions of Day 2 (Red-Nosed Reports).
+package day02
+
+// Safe reports whether the report follows PuzzlePolicy: the levels are either all increasing or all decreasing,
+// with two adjacent levels differing by at least one and at most three.
+func Safe(levels []int) bool {
+	return SafeWithRemovals(levels, 0)
+}
+
+// SafeWithRemovals reports whether the report follows PuzzlePolicy once at most k levels are removed (the Problem Dampener).
+//
+// The levels are scanned once per direction. For each level i and each number j of removals made so far,
+// the scan records whether a safe sequence can end by keeping level i. Level i can follow a kept level q
+// only if the levels between them are removed, so only the k+1 previous levels are candidates for q.
+// This is O(n·k²) time, linear for a fixed k, and no copy of the report is ever made.
+//
+// levels: The levels of the report. The slice is not modified.
+// k: The maximum number of levels the dampener may remove.
+// Returns: True if removing at most k levels makes the report safe.
+func SafeWithRemovals(levels []int, k int) bool {
+	p := PuzzlePolicy
+	p.Removals = k
+	return p.Safe(levels)
+}
+
+// Safe reports whether the report follows the policy once at most p.Removals levels are removed.
+// See SafeWithRemovals for the algorithm.
+//
+// levels: The levels of the report. The slice is not modified.
+// Returns: True if the report is safe according to the policy.
+func (p Policy) Safe(levels []int) bool {
+	_, ok := p.Removed(levels)
+	return ok
+}
+
+// Removed finds which levels to remove to make the report follow the policy, removing at most p.Removals levels.
+//
+// levels: The levels of the report. The slice is not modified.
+// Returns: The indexes of the removed levels in ascending order (empty if the report is already safe),
+// and false if no removal of at most p.Removals levels makes the report safe.
+func (p Policy) Removed(levels []int) ([]int, bool) {
+	n := len(levels)
+	k := p.Removals
+	if k < 0 {
+		return nil, false
+	}
+	// Keeping a single level (or none) is always safe.
+	if n-k <= 1 {
+		r := make([]int, max(n-1, 0))
+		for i := range r {
+			r[i] = i + 1
+		}
+		return r, true
+	}
+	if !p.ConsistentDirection {
+		return p.removed(levels, 0)
+	}
+	if r, ok := p.removed(levels, 1); ok {
+		return r, true
+	}
+	return p.removed(levels, -1)
+}
+
+// Sentinel values of the prev table of Policy.removed.
+const (
+	unreachable = -2 // unreachable marks a state that no safe sequence reaches.
+	first       = -1 // first marks a level kept after removing all the levels before it.
+)
+
+// removed is Policy.Removed for a single direction: +1 for increasing reports, -1 for decreasing reports,
+// or 0 when the direction may change between steps.
+func (p Policy) removed(levels []int, dir int) ([]int, bool) {
+	n := len(levels)
+	k := p.Removals
+
+	// prev[i*(k+1)+j] tells how a safe sequence in direction dir can end by keeping level i
+	// after removing exactly j of the levels before it: the index of the previous kept level,
+	// first if there is none, or unreachable if there is no such sequence.
+	prev := make([]int, n*(k+1))
+	for i := range prev {
+		prev[i] = unreachable
+	}
+
+	for i := 0; i < n; i++ {
+		// Level i can be the first kept level if all the levels before it are removed.
+		if i <= k {
+			prev[i*(k+1)+i] = first
+		}
+
+		// Otherwise it follows a kept level q, with the gap between them removed.
+		for q := i - 1; q >= 0 && i-q-1 <= k; q-- {
+			if !p.step(levels[q], levels[i], dir) {
+				continue
+			}
+			gap := i - q - 1
+			for j := gap; j <= k; j++ {
+				if prev[i*(k+1)+j] == unreachable && prev[q*(k+1)+j-gap] != unreachable {
+					prev[i*(k+1)+j] = q
+				}
+			}
+		}
+
+		// The report is safe if the levels after i can be removed within the remaining budget.
+		for j := 0; j <= k; j++ {
+			if prev[i*(k+1)+j] != unreachable && j+n-1-i <= k {
+				return backtrack(prev, k, n, i, j), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// backtrack walks the prev table of Policy.removed back from the last kept level
+// and returns the indexes of the levels that are not kept.
+func backtrack(prev []int, k, n, last, j int) []int {
+	kept := make([]bool, n)
+	for i := last; i != first; {
+		kept[i] = true
+		q := prev[i*(k+1)+j]
+		if q != first {
+			j -= i - q - 1
+		}
+		i = q
+	}
+
+	r := []int{}
+	for i, v := range kept {
+		if !v {
+			r = append(r, i)
+		}
+	}
+	return r
+}
+
+// SafeBruteForce is the reference implementation of Policy.Safe.
+// It tries every way of removing up to p.Removals levels and checks each resulting report,
+// which is exponential in the number of removals but simple enough to be trusted when cross-checking.
+func (p Policy) SafeBruteForce(levels []int) bool {
+	if p.follows(levels) {
+		return true
+	}
+	if p.Removals <= 0 {
+		return false
+	}
+	q := p
+	q.Removals--
+	for i := range levels {
+		if q.SafeBruteForce(without(levels, i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// follows reports whether every pair of adjacent levels follows the policy, without removing any level.
+func (p Policy) follows(levels []int) bool {
+	dirs := []int{1, -1}
+	if !p.ConsistentDirection {
+		dirs = []int{0}
+	}
+	for _, dir := range dirs {
+		ok := true
+		for i := 1; i < len(levels) && ok; i++ {
+			ok = p.step(levels[i-1], levels[i], dir)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// without returns a new slice holding the levels with the level at index i removed.
+// The input slice is never modified.
+func without(levels []int, i int) []int {
+	r := make([]int, 0, len(levels)-1)
+	r = append(r, levels[:i]...)
+	return append(r, levels[i+1:]...)
+}
@@ -0,0 +1,151 @@
+package day02
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomReport builds a random walk with small steps, sometimes flat or reversed,
+// so that many reports are nearly safe.
+func randomReport(rng *rand.Rand) []int {
+	levels := make([]int, rng.IntN(10))
+	v := rng.IntN(20)
+	for j := range levels {
+		v += rng.IntN(9) - 4
+		levels[j] = v
+	}
+	return levels
+}
+
+// randomPolicy returns the puzzle policy half of the time and a random one otherwise,
+// with up to maxK removals.
+func randomPolicy(rng *rand.Rand, maxK int) Policy {
+	p := PuzzlePolicy
+	if rng.IntN(2) == 0 {
+		p.MinStep = rng.IntN(3)
+		p.MaxStep = p.MinStep + rng.IntN(4)
+		p.AllowEqual = rng.IntN(2) == 0
+		p.ConsistentDirection = rng.IntN(2) == 0
+	}
+	p.Removals = rng.IntN(maxK + 1)
+	return p
+}
+
+// checkReport compares the linear check and the verdict of the policy with the brute-force reference
+// on a report, and checks that the removed levels are within the budget and leave a safe report.
+// None of the report utilities may modify the levels they are given.
+func checkReport(t *testing.T, p Policy, levels []int) {
+	t.Helper()
+	orig := slices.Clone(levels)
+	defer func() {
+		if !slices.Equal(levels, orig) {
+			t.Errorf("%+v modified the report %v into %v", p, orig, levels)
+		}
+	}()
+
+	got, want := p.Safe(levels), p.SafeBruteForce(levels)
+	if got != want {
+		t.Fatalf("%+v.Safe(%v) = %v, brute force says %v", p, levels, got, want)
+	}
+
+	// The verdict is SAFE without removal, SAFE_WITH_REMOVAL with some, and UNSAFE when no removal helps.
+	// It holds its own copy of the levels.
+	v := p.Judge(1, levels)
+	q := p
+	q.Removals = 0
+	switch {
+	case !want && v.Status != StatusUnsafe,
+		want && q.SafeBruteForce(levels) && v.Status != StatusSafe,
+		want && !q.SafeBruteForce(levels) && v.Status != StatusSafeRemoval:
+		t.Fatalf("%+v.Judge(%v) = %v", p, levels, v.Status)
+	}
+	if len(v.Levels) > 0 {
+		v.Levels[0]++
+	}
+
+	removed, ok := p.Removed(levels)
+	if ok != got {
+		t.Fatalf("%+v.Removed(%v) reports %v, Safe reports %v", p, levels, ok, got)
+	}
+	if !ok {
+		return
+	}
+	var rest []int
+	for j, v := range levels {
+		if !slices.Contains(removed, j) {
+			rest = append(rest, v)
+		}
+	}
+	if len(removed) > p.Removals || !q.Safe(rest) {
+		t.Fatalf("%+v.Removed(%v) = %v, which does not leave a safe report", p, levels, removed)
+	}
+}
+
+func TestSafeMatchesBruteForce(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n = 5000
+	}
+	rng := rand.New(rand.NewPCG(1, 1))
+	for range n {
+		checkReport(t, randomPolicy(rng, 3), randomReport(rng))
+	}
+}
+
+// FuzzSafe runs checkReport on fuzzed reports and policies. Each byte of the report is a level from 0 to 31.
+// Run it with go test -fuzz FuzzSafe ./day02.
+func FuzzSafe(f *testing.F) {
+	f.Add([]byte{7, 6, 4, 2, 1}, 1, 3, false, true, 0)
+	f.Add([]byte{1, 3, 2, 4, 5}, 1, 3, false, true, 1)
+	f.Add([]byte{8, 6, 4, 4, 1}, 1, 3, true, false, 2)
+	f.Add([]byte{1, 2, 7, 8, 9, 3, 3, 3}, 0, 5, false, false, 3)
+
+	f.Fuzz(func(t *testing.T, report []byte, minStep, maxStep int, allowEqual, consistent bool, removals int) {
+		// Keep the reports short and the budget small, since the brute force is exponential in both.
+		levels := make([]int, min(len(report), 12))
+		for i := range levels {
+			levels[i] = int(report[i] % 32)
+		}
+		p := Policy{
+			MinStep:             min(max(minStep, 0), 8),
+			MaxStep:             min(max(maxStep, 0), 8),
+			AllowEqual:          allowEqual,
+			Removals:            min(max(removals, 0), 3),
+			ConsistentDirection: consistent,
+		}
+		checkReport(t, p, levels)
+	})
+}
+
+func TestParseReportsNoAliasing(t *testing.T) {
+	reports, err := ParseReports("1 2 3\n4 5 6\n7 8 9\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Growing or changing a report must leave the next one untouched.
+	reports[0] = append(reports[0], 100)
+	reports[0][0] = -1
+	if want := []int{4, 5, 6}; !slices.Equal(reports[1], want) {
+		t.Errorf("second report = %v after changing the first one, want %v", reports[1], want)
+	}
+}
+
+func TestSafeWithRemovals(t *testing.T) {
+	// The reports of the example of the puzzle, safe with the dampener except the second and third ones.
+	reports := [][]int{
+		{7, 6, 4, 2, 1},
+		{1, 2, 7, 8, 9},
+		{9, 7, 6, 2, 1},
+		{1, 3, 2, 4, 5},
+		{8, 6, 4, 4, 1},
+		{1, 3, 6, 7, 9},
+	}
+	safe := []bool{true, false, false, true, true, true}
+	for i, levels := range reports {
+		if got := SafeWithRemovals(levels, 1); got != safe[i] {
+			t.Errorf("SafeWithRemovals(%v, 1) = %v, want %v", levels, got, safe[i])
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package day02
+
+import (
+	"context"
+
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+func init() {
+	runner.Register(2, 1, Part1)
+	runner.Register(2, 2, Part2)
+	runner.RegisterValidator(2, Validate)
+}
+
+// Part1 returns the number of safe reports of the puzzle input.
+func Part1(ctx context.Context, txt string) (int, error) {
+	return count(txt, PuzzlePolicy)
+}
+
+// Part2 returns the number of safe reports of the puzzle input once the Problem Dampener removes at most one level.
+func Part2(ctx context.Context, txt string) (int, error) {
+	p := PuzzlePolicy
+	p.Removals = 1
+	return count(txt, p)
+}
+
+// count returns the number of reports of the puzzle input that are safe according to the policy.
+func count(txt string, p Policy) (int, error) {
+	reports, err := ParseReports(txt)
+	if err != nil {
+		return 0, err
+	}
+	var valid int
+	for _, l := range reports {
+		if p.Safe(l) {
+			valid++
+		}
+	}
+	return valid, nil
+}
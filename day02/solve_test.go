@@ -0,0 +1,15 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/cl3mcg/aoc2024/example"
+)
+
+func TestPart1(t *testing.T) {
+	example.Check(t, 2, 1, Part1)
+}
+
+func TestPart2(t *testing.T) {
+	example.Check(t, 2, 2, Part2)
+}
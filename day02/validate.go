@@ -0,0 +1,29 @@
+package day02
+
+import "github.com/cl3mcg/aoc2024/puzzle"
+
+// Validate checks the puzzle input against the format of Day 2: every non-blank line is a report
+// of integer levels. Unlike ParseReports, it goes on after a problem and reports them all.
+//
+// txt: The content of the puzzle input.
+// Returns: A *puzzle.ParseError for each invalid level, or puzzle.ErrEmpty.
+func Validate(txt string) []error {
+	var errs []error
+	reports := 0
+	for _, line := range puzzle.Lines(txt) {
+		f := line.Fields()
+		if len(f) == 0 {
+			continue
+		}
+		for _, w := range f {
+			if _, err := line.Atoi(w, "invalid level"); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		reports++
+	}
+	if reports == 0 {
+		errs = append(errs, puzzle.ErrEmpty)
+	}
+	return errs
+}
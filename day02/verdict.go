@@ -0,0 +1,122 @@
+package day02
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Status is the overall verdict on a report.
+type Status string
+
+const (
+	StatusSafe        Status = "SAFE"              // StatusSafe is given to reports following the policy as they are.
+	StatusSafeRemoval Status = "SAFE_WITH_REMOVAL" // StatusSafeRemoval is given to reports made safe by the Problem Dampener.
+	StatusUnsafe      Status = "UNSAFE"            // StatusUnsafe is given to all the other reports.
+)
+
+// Reason explains why two adjacent levels break the policy.
+type Reason string
+
+const (
+	ReasonDirection Reason = "direction change"
+	ReasonZero      Reason = "zero step"
+	ReasonTooLarge  Reason = "step too large"
+	ReasonTooSmall  Reason = "step too small"
+)
+
+// Verdict explains the safety of a single report.
+type Verdict struct {
+	Report  int    `json:"report"`            // Report is the 1-based number of the report in the input.
+	Levels  []int  `json:"levels"`            // Levels are the levels of the report.
+	Status  Status `json:"status"`            // Status is the overall verdict.
+	Removed []int  `json:"removed,omitempty"` // Removed are the 0-based indexes removed by the dampener, for SAFE_WITH_REMOVAL.
+	Pair    []int  `json:"pair,omitempty"`    // Pair holds the 0-based indexes of the first offending pair of levels, for UNSAFE.
+	Reason  Reason `json:"reason,omitempty"`  // Reason explains why the first offending pair breaks the policy, for UNSAFE.
+}
+
+// Judge gives the verdict of the policy on a report. Safe reports needing the dampener
+// are given the smallest set of levels to remove. Unsafe reports are given the first pair of
+// adjacent levels breaking the policy, the direction being set by the first step that is not flat.
+//
+// n: The 1-based number of the report, copied into the verdict.
+// levels: The levels of the report. The slice is not modified.
+// Returns: The verdict on the report.
+func (p Policy) Judge(n int, levels []int) Verdict {
+	// The verdict keeps its own copy of the levels so that it never aliases the caller's slice.
+	v := Verdict{Report: n, Levels: slices.Clone(levels)}
+
+	// Try the smallest number of removals first so that the dampener removes as little as possible.
+	for r := 0; r <= p.Removals; r++ {
+		q := p
+		q.Removals = r
+		removed, ok := q.Removed(levels)
+		if !ok {
+			continue
+		}
+		if len(removed) == 0 {
+			v.Status = StatusSafe
+		} else {
+			v.Status = StatusSafeRemoval
+			v.Removed = removed
+		}
+		return v
+	}
+
+	v.Status = StatusUnsafe
+	dir := 0
+	for i := 1; i < len(levels); i++ {
+		d := levels[i] - levels[i-1]
+		reason := Reason("")
+		switch {
+		case d == 0 && !p.AllowEqual:
+			reason = ReasonZero
+		case d == 0:
+			// Equal levels are allowed and do not set the direction.
+		case p.ConsistentDirection && dir != 0 && d*dir < 0:
+			reason = ReasonDirection
+		case max(d, -d) > p.MaxStep:
+			reason = ReasonTooLarge
+		case max(d, -d) < p.MinStep:
+			reason = ReasonTooSmall
+		}
+		if reason != "" {
+			v.Pair = []int{i - 1, i}
+			v.Reason = reason
+			return v
+		}
+		if dir == 0 && d != 0 {
+			dir = 1
+			if d < 0 {
+				dir = -1
+			}
+		}
+	}
+	return v
+}
+
+// WriteVerdict writes a verdict either as a line of text or, if jsonl is true, as a line of JSON.
+func WriteVerdict(w io.Writer, v Verdict, jsonl bool) error {
+	if jsonl {
+		return json.NewEncoder(w).Encode(v)
+	}
+
+	var err error
+	switch v.Status {
+	case StatusSafe:
+		_, err = fmt.Fprintf(w, "report %d %v: %s\n", v.Report, v.Levels, v.Status)
+	case StatusSafeRemoval:
+		_, err = fmt.Fprintf(w, "report %d %v: %s, removed index %v\n", v.Report, v.Levels, v.Status, v.Removed)
+	default:
+		if v.Pair == nil {
+			// A report can be unsafe without an offending pair when the dampener is disabled with a negative budget.
+			_, err = fmt.Fprintf(w, "report %d %v: %s\n", v.Report, v.Levels, v.Status)
+			break
+		}
+		a, b := v.Levels[v.Pair[0]], v.Levels[v.Pair[1]]
+		_, err = fmt.Fprintf(w, "report %d %v: %s, %s between index %d and %d (%d -> %d)\n",
+			v.Report, v.Levels, v.Status, v.Reason, v.Pair[0], v.Pair[1], a, b)
+	}
+	return err
+}
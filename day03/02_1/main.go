@@ -1,13 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"log"
-	"log/slog"
 	"os"
-	"regexp"
-	"strconv"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day03"
+	"github.com/cl3mcg/aoc2024/logging"
 )
 
 // retrievePuzzleInput reads the puzzle input from a file at the specified path.
@@ -28,88 +27,86 @@ func retrievePuzzleInput(p string) (string, error) {
 }
 
 func main() {
-	// Read the puzzle input from the file "input.txt".
-	// If there's an error retrieving the input, log the error and stop execution.
-	txt, err := retrievePuzzleInput("../input.txt")
+	// Part two only knows mul, do and don't, other builtin instructions such as add and reset can be enabled.
+	ops := flag.String("ops", "mul,do,don't", "comma-separated list of the instructions to run: mul, do, don't, add, reset")
+	trace := flag.Bool("trace", false, "print every recognised instruction with its offset, state and contribution")
+	annotate := flag.Bool("annotate", false, "re-print the memory with the instructions highlighted and the disabled regions dimmed")
+	input := flag.String("input", "../input.txt", "path of the puzzle input")
+	stream := flag.Bool("stream", false, "read the memory through a bounded buffer instead of loading it, for very large dumps")
+	buffer := flag.Int("buffer", day03.StreamBufferSize, "with -stream, size of the read buffer in bytes")
+	arith := flag.String("arith", "int", "arithmetic of the result: int, checked (error on int64 overflow) or big")
+	digits := flag.Int("digits", day03.DefaultDigits, "largest number of digits of an operand, 3 in the puzzle; wider operands let -arith checked and big be tried on small inputs")
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
+	// Build the instruction set of the interpreter and choose its arithmetic.
+	reg, err := day03.NewRegistryByName(*ops)
 	if err != nil {
-		// Log a fatal error and terminate the program if the input file cannot be read.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error building the instruction set", "err", err)
+	}
+	if err := reg.SetDigits(*digits); err != nil {
+		logging.Fatal(logger, "Error building the instruction set", "err", err)
+	}
+	a, err := day03.ParseArith(*arith)
+	if err != nil {
+		logging.Fatal(logger, "Error choosing the arithmetic", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input to clean it.
-	txt = strings.TrimSpace(txt)
-
-	// Split the input string by the keyword "do" to isolate the 'do' and 'don't' instructions.
-	l := strings.SplitN(txt, "do", -1)
-
-	// Initialize a slice to store segments that contain valid "do()" instructions.
-	var do []string
+	// In streaming mode, the memory is never loaded as a whole and the trace is written as it goes.
+	if *stream {
+		if *annotate {
+			logging.Fatal(logger, "The annotated view needs the whole memory and cannot be used with -stream")
+		}
+		f, err := os.Open(*input)
+		if err != nil {
+			logging.Fatal(logger, "Error opening the puzzle input", "err", err)
+		}
+		defer f.Close()
 
-	// Loop through each segment and extract the valid "do()" or empty "()" instructions.
-	for i, v := range l {
-		if i == 0 {
-			// The first segment (before any 'do') is added as-is.
-			do = append(do, v)
-			continue
+		var visit func(day03.Step)
+		if *trace {
+			visit = func(st day03.Step) {
+				if err := day03.WriteStep(os.Stdout, st); err != nil {
+					logging.Fatal(logger, "Error writing the trace", "err", err)
+				}
+			}
 		}
-		if v[0:2] == "()" {
-			// If the segment starts with '()', it's a valid 'do()' instruction.
-			do = append(do, v)
+		res, err := day03.RunScanner(day03.NewScanner(f, reg, *buffer), reg, a, visit)
+		if err != nil {
+			logging.Fatal(logger, "Error running the puzzle input", "err", err)
 		}
+		fmt.Println("The result 'r' should be: ", res)
+		return
 	}
 
-	// Combine the valid "do()" instructions into a single string.
-	cl := strings.Join(do, "")
-
-	// Split the string by "mul" to process each multiplication instruction separately.
-	e := strings.SplitN(cl, "mul", -1)
-
-	// Initialize a slice to store valid "mul" instructions after parsing.
-	var f []string
-
-	// Loop through the segments and check if they match the pattern for a valid "mul(X,Y)" instruction.
-	for _, v := range e {
-		// Use a regular expression to check if the segment contains a valid "mul(X,Y)" pattern.
-		match, _ := regexp.MatchString(`\(\d{1,4},\d{1,4}\)`, v)
-		// If the pattern matches, add the segment to the list of valid instructions.
-		if match {
-			f = append(f, v)
-		}
+	// Read the puzzle input from the file "input.txt".
+	// If there's an error retrieving the input, log the error and stop execution.
+	txt, err := retrievePuzzleInput(*input)
+	if err != nil {
+		// Log a fatal error and terminate the program if the input file cannot be read.
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Initialize a variable to hold the sum of all valid multiplication results.
-	var r int
+	// Scan the corrupted memory once and run its instructions.
+	res, err := day03.Interpret(txt, reg, a)
+	if err != nil {
+		logging.Fatal(logger, "Error running the puzzle input", "err", err)
+	}
 
-	// Loop through each valid "mul" instruction in the list.
-	for _, v := range f {
-		// Skip the instruction if it doesn't start with a parenthesis.
-		if v[0] != '(' {
-			continue
+	// Print the requested debugging views before the answer.
+	if *trace {
+		if err := day03.WriteTrace(os.Stdout, res); err != nil {
+			logging.Fatal(logger, "Error writing the trace", "err", err)
 		}
-		// Extract the string inside the parentheses by cutting off the part before "(".
-		_, bf, _ := strings.Cut(v, "(")
-		// Extract the content inside the parentheses, removing the closing ")".
-		af, _, _ := strings.Cut(bf, ")")
-		// Split the string inside the parentheses by the comma to separate the two numbers.
-		sl := strings.Split(af, ",")
-		// Convert the first number to an integer.
-		n1, err := strconv.Atoi(sl[0])
-		if err != nil {
-			// Log an error if parsing the first number fails.
-			slog.Error("Error parsing string to int", "v", v, "af", af, "sl[0]", sl[0])
-			continue
-		}
-		// Convert the second number to an integer.
-		n2, err := strconv.Atoi(sl[1])
-		if err != nil {
-			// Log an error if parsing the second number fails.
-			slog.Error("Error parsing string to int", "v", v, "af", af, "sl[1]", sl[1])
-			continue
+	}
+	if *annotate {
+		if err := day03.WriteAnnotated(os.Stdout, txt, res); err != nil {
+			logging.Fatal(logger, "Error writing the annotated memory", "err", err)
 		}
-		// Multiply the two numbers and add the result to the total sum.
-		r = r + (n1 * n2)
+		fmt.Println()
 	}
 
-	// Print the final sum of all valid multiplications.
-	fmt.Println("The result 'r' should be: ", r)
+	// Print the final sum of all valid multiplications, along with the arithmetic used.
+	fmt.Println("The result 'r' should be: ", res)
 }
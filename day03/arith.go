@@ -0,0 +1,127 @@
+package day03
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Arith selects how the machine accumulates its result.
+type Arith string
+
+const (
+	ArithInt     Arith = "int"     // ArithInt uses a plain int, silently wrapping around on overflow.
+	ArithChecked Arith = "checked" // ArithChecked uses int64 and stops with ErrOverflow on overflow.
+	ArithBig     Arith = "big"     // ArithBig uses a big.Int, which never overflows.
+)
+
+// ParseArith checks the name of an arithmetic mode.
+func ParseArith(s string) (Arith, error) {
+	switch a := Arith(s); a {
+	case ArithInt, ArithChecked, ArithBig:
+		return a, nil
+	}
+	return "", fmt.Errorf("unknown arithmetic %q, expected int, checked or big", s)
+}
+
+// ErrOverflow is reported by checked arithmetic when the result does not fit in an int64.
+var ErrOverflow = errors.New("int64 overflow")
+
+// Machine is the state of the interpreter.
+type Machine struct {
+	Enabled bool  // Enabled tells whether the instructions that can be disabled are executed.
+	Arith   Arith // Arith is the arithmetic used for the accumulated result, ArithInt if empty.
+
+	acc   int      // acc is the accumulated result in ArithInt and ArithChecked modes.
+	big   *big.Int // big is the accumulated result in ArithBig mode.
+	delta *big.Int // delta is the change caused by the current instruction, for the trace, exact in every mode.
+	err   error    // err is the first overflow met in ArithChecked mode.
+}
+
+// NewMachine returns a machine enabled at the start, using the given arithmetic.
+func NewMachine(a Arith) *Machine {
+	return &Machine{Enabled: true, Arith: a, big: new(big.Int), delta: new(big.Int)}
+}
+
+// Add adds v to the accumulated result.
+func (m *Machine) Add(v int) {
+	m.AddProduct(v, 1)
+}
+
+// AddProduct adds a*b to the accumulated result.
+// In ArithChecked mode, an overflow of the product or of the sum stops the machine with ErrOverflow.
+func (m *Machine) AddProduct(a, b int) {
+	switch m.Arith {
+	case ArithBig:
+		p := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		m.big.Add(m.big, p)
+		// The product of two wide operands may not fit in an int64, so the delta stays a big.Int too.
+		m.delta.Set(p)
+	case ArithChecked:
+		if m.err != nil {
+			return
+		}
+		// The accumulated result is only updated without overflow, so that the error tells the value reached.
+		p, ok := mul64(int64(a), int64(b))
+		var s int64
+		if ok {
+			s, ok = add64(int64(m.acc), p)
+		}
+		if !ok {
+			m.err = fmt.Errorf("adding %d*%d to %d: %w", a, b, m.acc, ErrOverflow)
+			return
+		}
+		m.acc = int(s)
+		m.delta.SetInt64(p)
+	default:
+		m.acc += a * b
+		m.delta.SetInt64(int64(a * b))
+	}
+}
+
+// Reset sets the accumulated result back to zero.
+func (m *Machine) Reset() {
+	// The delta is negated as a big.Int, since neither -math.MinInt64 nor a big result fits in an int64.
+	if m.Arith == ArithBig {
+		m.delta.Neg(m.big)
+	} else {
+		m.delta.Neg(big.NewInt(int64(m.acc)))
+	}
+	m.acc = 0
+	m.big.SetInt64(0)
+}
+
+// Value returns the accumulated result as a big.Int, whatever the arithmetic.
+func (m *Machine) Value() *big.Int {
+	if m.Arith == ArithBig {
+		return new(big.Int).Set(m.big)
+	}
+	return big.NewInt(int64(m.acc))
+}
+
+// Err returns the overflow met in ArithChecked mode, if any.
+func (m *Machine) Err() error {
+	return m.err
+}
+
+// mul64 multiplies two int64 and reports whether the product did not overflow.
+func mul64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return p, true
+}
+
+// add64 adds two int64 and reports whether the sum did not overflow.
+func add64(a, b int64) (int64, bool) {
+	s := a + b
+	if (b > 0 && s < a) || (b < 0 && s > a) {
+		return 0, false
+	}
+	return s, true
+}
@@ -0,0 +1,104 @@
+package day03
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCheckedOverflowKeepsResult(t *testing.T) {
+	m := NewMachine(ArithChecked)
+	m.AddProduct(math.MaxInt64-5, 1)
+	m.AddProduct(10, 1)
+
+	if !errors.Is(m.Err(), ErrOverflow) {
+		t.Fatalf("Err() = %v, want ErrOverflow", m.Err())
+	}
+	if want := "adding 10*1 to 9223372036854775802"; !strings.Contains(m.Err().Error(), want) {
+		t.Errorf("Err() = %q, want it to contain %q", m.Err(), want)
+	}
+	if got := m.Value().Int64(); got != math.MaxInt64-5 {
+		t.Errorf("Value() = %d after the overflow, want %d", got, int64(math.MaxInt64-5))
+	}
+}
+
+func TestDigits(t *testing.T) {
+	mem := "mul(1234,5)mul(12,34)"
+
+	// The puzzle operands have at most 3 digits, so the first instruction is ignored.
+	res, err := Interpret(mem, Part1Registry(), ArithInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.Value.Int64(); got != 408 {
+		t.Errorf("with 3 digits, result = %d, want 408", got)
+	}
+
+	reg := Part1Registry()
+	if err := reg.SetDigits(4); err != nil {
+		t.Fatal(err)
+	}
+	res, err = Interpret(mem, reg, ArithInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.Value.Int64(); got != 6578 {
+		t.Errorf("with 4 digits, result = %d, want 6578", got)
+	}
+
+	if err := reg.SetDigits(MaxDigits + 1); err == nil {
+		t.Errorf("SetDigits(%d) succeeded, want an error", MaxDigits+1)
+	}
+}
+
+func TestCheckedOverflowWithWideOperands(t *testing.T) {
+	reg := Part1Registry()
+	if err := reg.SetDigits(MaxDigits); err != nil {
+		t.Fatal(err)
+	}
+	mem := strings.Repeat("mul(999999999999999999,999999999999999999)", 2)
+
+	if _, err := Interpret(mem, reg, ArithChecked); !errors.Is(err, ErrOverflow) {
+		t.Errorf("checked arithmetic: err = %v, want ErrOverflow", err)
+	}
+	res, err := Interpret(mem, reg, ArithBig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1999999999999999996000000000000000002"; res.Value.String() != want {
+		t.Errorf("big arithmetic: result = %s, want %s", res.Value, want)
+	}
+}
+
+func TestTraceWithWideOperands(t *testing.T) {
+	reg := NewRegistry(MulInstruction, ResetInstruction)
+	if err := reg.SetDigits(MaxDigits); err != nil {
+		t.Fatal(err)
+	}
+	mem := strings.Repeat("mul(999999999999999999,999999999999999999)", 2) + "reset()"
+	res, err := Interpret(mem, reg, ArithBig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The deltas do not fit in an int64 and must be traced exactly, the reset taking back the whole result.
+	var b strings.Builder
+	if err := WriteTrace(&b, res); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	want := []string{
+		"+999999999999999998000000000000000001",
+		"+999999999999999998000000000000000001",
+		"-1999999999999999996000000000000000002",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("trace has %d lines, want %d:\n%s", len(lines), len(want), b.String())
+	}
+	for i, l := range lines {
+		if !strings.HasSuffix(l, "  "+want[i]) {
+			t.Errorf("trace line %d = %q, want the delta %s", i+1, l, want[i])
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package day03
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// Instruction describes an instruction that the interpreter can recognise and execute.
+type Instruction struct {
+	Name  string                // Name is the name written before the parenthesis, e.g. "mul".
+	Arity int                   // Arity is the number of operands between the parentheses.
+	Gated bool                  // Gated instructions are skipped while the machine is disabled.
+	Exec  func(*Machine, []int) // Exec applies the instruction with its operands to the machine.
+}
+
+// The instructions of the puzzle, and a few more to show how the language can be extended.
+var (
+	MulInstruction   = Instruction{Name: "mul", Arity: 2, Gated: true, Exec: func(m *Machine, a []int) { m.AddProduct(a[0], a[1]) }}
+	DoInstruction    = Instruction{Name: "do", Exec: func(m *Machine, _ []int) { m.Enabled = true }}
+	DontInstruction  = Instruction{Name: "don't", Exec: func(m *Machine, _ []int) { m.Enabled = false }}
+	AddInstruction   = Instruction{Name: "add", Arity: 2, Gated: true, Exec: func(m *Machine, a []int) { m.Add(a[0] + a[1]) }}
+	ResetInstruction = Instruction{Name: "reset", Gated: true, Exec: func(m *Machine, _ []int) { m.Reset() }}
+)
+
+// Builtins lists the instructions known by name, as accepted by NewRegistryByName.
+var Builtins = []Instruction{MulInstruction, DoInstruction, DontInstruction, AddInstruction, ResetInstruction}
+
+// Registry is the set of instructions recognised by the lexer and executed by the interpreter.
+type Registry struct {
+	ins    map[string]Instruction
+	names  []string // names are sorted longest first for the lexer.
+	digits int      // digits is the largest number of digits of an operand, DefaultDigits if zero.
+}
+
+// NewRegistry creates a registry holding the given instructions.
+func NewRegistry(ins ...Instruction) *Registry {
+	reg := &Registry{ins: make(map[string]Instruction)}
+	for _, in := range ins {
+		reg.Register(in)
+	}
+	return reg
+}
+
+// NewRegistryByName creates a registry from a comma-separated list of builtin instruction names, e.g. "mul,do,don't".
+func NewRegistryByName(list string) (*Registry, error) {
+	reg := NewRegistry()
+	for _, name := range strings.Split(list, ",") {
+		i := slices.IndexFunc(Builtins, func(in Instruction) bool { return in.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown instruction %q", name)
+		}
+		reg.Register(Builtins[i])
+	}
+	return reg, nil
+}
+
+// maxLen returns the length of the longest instruction of the registry, with all operands at their widest.
+func (reg *Registry) maxLen() int {
+	n := 0
+	for _, in := range reg.ins {
+		// name, parentheses, operands and the commas between them.
+		l := len(in.Name) + 2 + in.Arity*reg.Digits() + max(in.Arity-1, 0)
+		n = max(n, l)
+	}
+	return n
+}
+
+// SetDigits sets the largest number of digits of an operand, from 1 to MaxDigits. Wider operands than
+// the 3 digits of the puzzle make the checked and big arithmetic modes useful on reasonably sized inputs.
+func (reg *Registry) SetDigits(n int) error {
+	if n < 1 || n > MaxDigits {
+		return fmt.Errorf("invalid operand width %d, expected 1 to %d digits", n, MaxDigits)
+	}
+	reg.digits = n
+	return nil
+}
+
+// Digits returns the largest number of digits of an operand.
+func (reg *Registry) Digits() int {
+	if reg.digits == 0 {
+		return DefaultDigits
+	}
+	return reg.digits
+}
+
+// Register adds an instruction to the registry, replacing any instruction with the same name.
+func (reg *Registry) Register(in Instruction) {
+	if _, ok := reg.ins[in.Name]; !ok {
+		reg.names = append(reg.names, in.Name)
+		slices.SortStableFunc(reg.names, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	}
+	reg.ins[in.Name] = in
+}
+
+// Part1Registry holds the instructions of part one: only mul.
+func Part1Registry() *Registry {
+	return NewRegistry(MulInstruction)
+}
+
+// Part2Registry holds the instructions of part two: mul, do and don't.
+func Part2Registry() *Registry {
+	return NewRegistry(MulInstruction, DoInstruction, DontInstruction)
+}
+
+// Step records what the interpreter did with a token.
+type Step struct {
+	Token
+	Enabled  bool     // Enabled is the state of the machine when the instruction was reached.
+	Executed bool     // Executed is false if the instruction was skipped because the machine was disabled.
+	Delta    *big.Int // Delta is the change of the accumulated result caused by the instruction, zero if skipped.
+}
+
+// Result is the outcome of running a program.
+type Result struct {
+	Value   *big.Int // Value is the accumulated result at the end of the program.
+	Arith   Arith    // Arith is the arithmetic used to compute Value.
+	Enabled bool     // Enabled is the state of the machine at the end of the program.
+	Trace   []Step   // Trace lists every instruction in order, executed or skipped.
+}
+
+// String formats the answer with the arithmetic used to compute it.
+func (r Result) String() string {
+	return fmt.Sprintf("%v (%s arithmetic)", r.Value, r.Arith)
+}
+
+// result builds the result of a machine at the end of a program.
+func result(m *Machine, trace []Step) (Result, error) {
+	res := Result{Value: m.Value(), Arith: m.Arith, Enabled: m.Enabled, Trace: trace}
+	if res.Arith == "" {
+		res.Arith = ArithInt
+	}
+	return res, m.Err()
+}
+
+// Run executes the tokens on a fresh machine, enabled at the start.
+// Gated instructions are skipped while the machine is disabled, the other ones are always executed.
+//
+// ts: The tokens produced by Lex with the same registry.
+// reg: The registry defining the instructions.
+// a: The arithmetic used to accumulate the result.
+// Returns: The accumulated result and the trace of every instruction, or ErrOverflow with checked arithmetic.
+func Run(ts []Token, reg *Registry, a Arith) (Result, error) {
+	m := NewMachine(a)
+	trace := make([]Step, 0, len(ts))
+	for _, t := range ts {
+		trace = append(trace, reg.exec(m, t))
+		if m.Err() != nil {
+			break
+		}
+	}
+	return result(m, trace)
+}
+
+// exec executes a single token on the machine, unless the instruction is gated and the machine disabled.
+func (reg *Registry) exec(m *Machine, t Token) Step {
+	in := reg.ins[t.Name]
+	st := Step{Token: t, Enabled: m.Enabled, Delta: new(big.Int)}
+	if !in.Gated || m.Enabled {
+		// Each step gets its own delta, which the instruction sets through the machine.
+		m.delta = st.Delta
+		in.Exec(m, t.Args)
+		st.Executed = true
+	}
+	return st
+}
+
+// Interpret scans the memory and runs the instructions of the registry it contains.
+func Interpret(mem string, reg *Registry, a Arith) (Result, error) {
+	return Run(Lex(mem, reg), reg, a)
+}
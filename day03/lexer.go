@@ -0,0 +1,103 @@
+// Package day03 holds the logic shared by the solutions of Day 3 (Mull It Over).
+package day03
+
+// Token is an instruction found in the corrupted memory.
+type Token struct {
+	Name   string // Name is the name of the instruction, e.g. "mul" or "don't".
+	Offset int    // Offset is the byte offset of the first character of the instruction in the memory.
+	Text   string // Text is the instruction exactly as written in the memory, e.g. "mul(2,4)".
+	Args   []int  // Args are the operands of the instruction, e.g. [2 4].
+}
+
+// DefaultDigits is the largest number of digits of an operand in the puzzle.
+const DefaultDigits = 3
+
+// MaxDigits is the largest operand width a registry accepts, since an operand of 18 digits always fits in an int64.
+const MaxDigits = 18
+
+// Lex scans the corrupted memory once and returns the instructions of the registry it contains, in order.
+// An instruction must be written exactly "name(X,Y,...)" with as many operands as its arity, each operand
+// being a number of 1 to reg.Digits() digits (3 in the puzzle), without any space. Every other sequence of characters is ignored,
+// including the start of an instruction that turns out to be invalid, so "mul(4*mul(2,3)" still yields "mul(2,3)".
+//
+// mem: The content of the corrupted memory.
+// reg: The instructions to recognise.
+// Returns: The tokens of the valid instructions with their byte offsets.
+func Lex(mem string, reg *Registry) []Token {
+	var ts []Token
+	for i := 0; i < len(mem); {
+		t, n := match(reg, mem[i:])
+		if n == 0 {
+			// No instruction starts here, try from the next character.
+			i++
+			continue
+		}
+		t.Offset = i
+		ts = append(ts, t)
+		i += n
+	}
+	return ts
+}
+
+// match recognises an instruction of the registry at the start of s.
+// It returns the token (without its offset) and its length, or a length of zero if s does not start with an instruction.
+// It works on strings for Lex and on byte slices for the Scanner, without converting the input.
+func match[T ~string | ~[]byte](reg *Registry, s T) (Token, int) {
+	// Names are tried longest first, so that "don't(" is not mistaken for an invalid "do(".
+	for _, name := range reg.names {
+		if !hasCall(s, name) {
+			continue
+		}
+		i := len(name) + 1
+		args := make([]int, reg.ins[name].Arity)
+		for j := range args {
+			// Operands are separated by commas.
+			if j > 0 {
+				if i >= len(s) || s[i] != ',' {
+					return Token{}, 0
+				}
+				i++
+			}
+			v, n := number(s[i:], reg.Digits())
+			if n == 0 {
+				return Token{}, 0
+			}
+			args[j] = v
+			i += n
+		}
+		if i >= len(s) || s[i] != ')' {
+			return Token{}, 0
+		}
+		i++
+		return Token{Name: name, Text: string(s[:i]), Args: args}, i
+	}
+	return Token{}, 0
+}
+
+// hasCall reports whether s begins with the name followed by an opening parenthesis.
+func hasCall[T ~string | ~[]byte](s T, name string) bool {
+	if len(s) < len(name)+1 || s[len(name)] != '(' {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if s[i] != name[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// number reads a number of 1 to digits digits at the start of s.
+// It returns the number and its length, or a length of zero if s does not start with such a number.
+// A longer run of digits is rejected as a whole rather than truncated.
+func number[T ~string | ~[]byte](s T, digits int) (int, int) {
+	v, n := 0, 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		if n == digits {
+			return 0, 0
+		}
+		v = v*10 + int(s[n]-'0')
+		n++
+	}
+	return v, n
+}
@@ -0,0 +1,26 @@
+package day03
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+func init() {
+	runner.Register(3, 1, Part1)
+	runner.Register(3, 2, Part2)
+	runner.RegisterValidator(3, Validate)
+}
+
+// Part1 returns the sum of the products of all the mul instructions of the memory.
+func Part1(ctx context.Context, txt string) (*big.Int, error) {
+	res, err := Interpret(txt, Part1Registry(), ArithInt)
+	return res.Value, err
+}
+
+// Part2 returns the sum of the products of the enabled mul instructions of the memory.
+func Part2(ctx context.Context, txt string) (*big.Int, error) {
+	res, err := Interpret(txt, Part2Registry(), ArithInt)
+	return res.Value, err
+}
@@ -0,0 +1,96 @@
+package day03
+
+import (
+	"bufio"
+	"io"
+)
+
+// StreamBufferSize is the default size of the buffer of a Scanner.
+const StreamBufferSize = 64 << 10
+
+// Scanner reads the instructions of a registry from a memory dump of any size, in constant memory.
+// The dump is read through a fixed-size buffer. Before matching at a position, the scanner makes sure that
+// the longest possible instruction fits in the buffer, so instructions split across two reads are still found.
+type Scanner struct {
+	r   *bufio.Reader
+	reg *Registry
+	max int   // max is the length of the longest instruction of the registry.
+	off int   // off is the byte offset of the next unread byte.
+	tok Token // tok is the last token found.
+	err error // err is the first error other than io.EOF.
+}
+
+// NewScanner creates a scanner reading from r with a buffer of size bytes (StreamBufferSize if size is not positive).
+func NewScanner(r io.Reader, reg *Registry, size int) *Scanner {
+	if size <= 0 {
+		size = StreamBufferSize
+	}
+	m := max(reg.maxLen(), 1)
+	return &Scanner{r: bufio.NewReaderSize(r, max(size, m)), reg: reg, max: m}
+}
+
+// Scan advances to the next instruction, which is then available through Token.
+// It returns false at the end of the dump or on a read error, reported by Err.
+func (sc *Scanner) Scan() bool {
+	for {
+		// Peek returns fewer bytes only at the end of the dump (or on an error).
+		b, err := sc.r.Peek(sc.max)
+		if len(b) == 0 {
+			if err != io.EOF {
+				sc.err = err
+			}
+			return false
+		}
+		if err != nil && err != io.EOF {
+			sc.err = err
+			return false
+		}
+
+		t, n := match(sc.reg, b)
+		if n == 0 {
+			// No instruction starts here, try from the next byte.
+			sc.r.Discard(1)
+			sc.off++
+			continue
+		}
+
+		t.Offset = sc.off
+		sc.tok = t
+		sc.r.Discard(n)
+		sc.off += n
+		return true
+	}
+}
+
+// Token returns the instruction found by the last call to Scan.
+func (sc *Scanner) Token() Token {
+	return sc.tok
+}
+
+// Err returns the first read error met by the scanner, if any.
+func (sc *Scanner) Err() error {
+	return sc.err
+}
+
+// RunScanner executes the instructions read by the scanner on a fresh machine, enabled at the start.
+// The state of the machine, including do() and don't(), carries over the whole dump whatever the buffer size.
+// The trace is not kept, to stay in constant memory: visit, if not nil, is called with every step instead.
+//
+// sc: The scanner, created with the same registry.
+// reg: The registry defining the instructions.
+// a: The arithmetic used to accumulate the result.
+// visit: An optional function receiving every step, e.g. to write a trace.
+// Returns: The result without its trace, or an error if the dump cannot be read or with checked arithmetic on overflow.
+func RunScanner(sc *Scanner, reg *Registry, a Arith, visit func(Step)) (Result, error) {
+	m := NewMachine(a)
+	for m.Err() == nil && sc.Scan() {
+		st := reg.exec(m, sc.Token())
+		if visit != nil {
+			visit(st)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return Result{}, err
+	}
+	return result(m, nil)
+}
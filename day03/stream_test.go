@@ -0,0 +1,52 @@
+package day03
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// randomMemory glues n random pieces of instructions, so that some instructions are complete,
+// some are cut or malformed, and some only appear once two pieces are put together.
+func randomMemory(rng *rand.Rand, n int) string {
+	pieces := []string{"mul(", "2,", "3)", "don't()", "do()", "mul(1234,5)", "mul(4*", "mul(2,3)", "x", ")", "do", "n't()"}
+	var b strings.Builder
+	for range n {
+		b.WriteString(pieces[rng.IntN(len(pieces))])
+	}
+	return b.String()
+}
+
+func TestRunScannerMatchesInterpret(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 3))
+	reg := Part2Registry()
+	for range 500 {
+		mem := randomMemory(rng, rng.IntN(40))
+		want, err := Interpret(mem, reg, ArithInt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Reading one byte at a time with the smallest buffer splits every instruction across reads.
+		var steps []Step
+		sc := NewScanner(iotest.OneByteReader(strings.NewReader(mem)), reg, 1)
+		got, err := RunScanner(sc, reg, ArithInt, func(st Step) { steps = append(steps, st) })
+		if err != nil {
+			t.Fatalf("RunScanner(%q): %v", mem, err)
+		}
+
+		if got.Value.Cmp(want.Value) != 0 || got.Enabled != want.Enabled {
+			t.Errorf("RunScanner(%q) = %v, enabled %v, Interpret gives %v, enabled %v", mem, got.Value, got.Enabled, want.Value, want.Enabled)
+		}
+		if len(steps) != len(want.Trace) {
+			t.Fatalf("RunScanner(%q) visits %d steps, Interpret traces %d", mem, len(steps), len(want.Trace))
+		}
+		for i, st := range steps {
+			w := want.Trace[i]
+			if st.Offset != w.Offset || st.Text != w.Text || st.Enabled != w.Enabled || st.Executed != w.Executed || st.Delta.Cmp(w.Delta) != 0 {
+				t.Errorf("RunScanner(%q) step %d = %+v, Interpret gives %+v", mem, i, st, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package day03
+
+import (
+	"fmt"
+	"io"
+)
+
+// ANSI escape sequences used by WriteAnnotated.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiDim    = "\x1b[2m"
+	ansiGreen  = "\x1b[1;32m" // ansiGreen highlights the executed instructions changing the result.
+	ansiCyan   = "\x1b[1;36m" // ansiCyan highlights the other executed instructions, such as do() and don't().
+	ansiStrike = "\x1b[2;9m"  // ansiStrike marks the instructions skipped while the machine is disabled.
+)
+
+// WriteTrace writes one line per recognised instruction: its byte offset, its text,
+// whether the machine was enabled, whether it was executed and its contribution to the result.
+//
+// w: The destination of the trace.
+// res: The result of Run, holding the trace.
+// Returns: An error if writing to w fails.
+func WriteTrace(w io.Writer, res Result) error {
+	for _, st := range res.Trace {
+		if err := WriteStep(w, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStep writes the trace line of a single step, as WriteTrace does.
+func WriteStep(w io.Writer, st Step) error {
+	state := "enabled"
+	if !st.Enabled {
+		state = "disabled"
+	}
+	action := "executed"
+	if !st.Executed {
+		action = "skipped"
+	}
+	_, err := fmt.Fprintf(w, "%8d  %-14s %-8s  %-8s  %+d\n", st.Offset, st.Text, state, action, st.Delta)
+	return err
+}
+
+// WriteAnnotated re-prints the corrupted memory with ANSI escapes: the executed instructions are highlighted
+// and the regions where the machine is disabled are dimmed, with their skipped instructions struck through.
+//
+// w: The destination of the annotated memory, usually a terminal.
+// mem: The corrupted memory given to Lex.
+// res: The result of running the tokens found in mem.
+// Returns: An error if writing to w fails.
+func WriteAnnotated(w io.Writer, mem string, res Result) error {
+	// plain writes the memory between two instructions, dimmed if the machine is disabled.
+	plain := func(s string, enabled bool) error {
+		if s == "" {
+			return nil
+		}
+		if enabled {
+			_, err := io.WriteString(w, s)
+			return err
+		}
+		_, err := io.WriteString(w, ansiDim+s+ansiReset)
+		return err
+	}
+
+	// The memory before an instruction takes the state of the machine when the instruction is reached.
+	pos := 0
+	for _, st := range res.Trace {
+		if err := plain(mem[pos:st.Offset], st.Enabled); err != nil {
+			return err
+		}
+
+		color := ansiStrike
+		switch {
+		case st.Executed && st.Delta.Sign() != 0:
+			color = ansiGreen
+		case st.Executed:
+			color = ansiCyan
+		}
+		if _, err := io.WriteString(w, color+st.Text+ansiReset); err != nil {
+			return err
+		}
+		pos = st.Offset + len(st.Text)
+	}
+
+	// The memory after the last instruction takes the final state of the machine.
+	return plain(mem[pos:], res.Enabled)
+}
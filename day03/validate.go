@@ -0,0 +1,23 @@
+package day03
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// ErrNoInstruction is reported when the memory holds no valid instruction at all.
+var ErrNoInstruction = errors.New("no mul, do or don't instruction found in the memory")
+
+// Validate checks the puzzle input against the format of Day 3. The memory is corrupted on purpose,
+// so any text is valid as long as it holds at least one instruction of part two.
+func Validate(txt string) []error {
+	if strings.TrimSpace(txt) == "" {
+		return []error{puzzle.ErrEmpty}
+	}
+	if len(Lex(txt, Part2Registry())) == 0 {
+		return []error{ErrNoInstruction}
+	}
+	return nil
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"log"
 	"os"
 	"strings"
+
+	"github.com/cl3mcg/aoc2024/day04"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the puzzle input from a file at the specified path.
@@ -243,30 +247,34 @@ func check1030(input Input, indexL, indexC int) bool {
 // main reads the puzzle input from a file, processes it, and counts occurrences of the word "XMAS"
 // in all 8 possible directions in the puzzle grid. It prints the final count.
 func main() {
+	// Log to the standard error, with the level and format chosen by -v, -q and -log-format.
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
 	// Read the puzzle input from the file "input.txt".
 	// If there's an error retrieving the input, log the error and stop execution.
 	txt, err := retrievePuzzleInput("../input.txt")
 	if err != nil {
 		// Log a fatal error and terminate the program if the input file cannot be read.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input to clean it.
-	txt = strings.TrimSpace(txt)
-
-	// Split the input text into lines based on newline characters.
-	ls := strings.Split(txt, "\n")
+	// Parse the puzzle input into a grid of upper-case letters, stopping on a malformed input with its position.
+	g, err := day04.Parse(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, "../input.txt"))
+		os.Exit(1)
+	}
 
-	// Initialize an Input object to store the puzzle content.
+	// Initialize an Input object to store the puzzle content as lines.
 	var inputLines Input
 
-	// Process each line of the puzzle and add it to the inputLines.Content slice.
-	for i, v := range ls {
-		v = strings.TrimSpace(v)
-		v = strings.Trim(v, "\n")
-		v = strings.Trim(v, "\r")
+	// Each line of the grid is split into individual characters and added to the content of the puzzle.
+	for i, v := range g {
 		var l Line
-		l.Content = strings.SplitN(v, "", -1)
+		l.Content = strings.Split(v, "")
 		l.Index = i
 		inputLines.Content = append(inputLines.Content, l)
 	}
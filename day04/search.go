@@ -0,0 +1,114 @@
+// Package day04 holds the logic of Day 4 (Ceres Search), shared with the aoc runner.
+package day04
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+func init() {
+	runner.Register(4, 1, Part1)
+	runner.Register(4, 2, Part2)
+	runner.RegisterValidator(4, Validate)
+}
+
+// Grid is the word search, one string per line.
+type Grid []string
+
+// Errors reported by Parse, wrapped in a *puzzle.ParseError.
+var (
+	ErrLetter = errors.New("expected a letter")
+	ErrWidth  = errors.New("the lines of the grid do not have the same length")
+)
+
+// Parse splits the puzzle input into the lines of the grid, ignoring blank lines and CRLF line endings.
+// The grid must be rectangular and hold letters only, in any case.
+//
+// txt: The content of the puzzle input.
+// Returns: The grid in upper case, or a *puzzle.ParseError on the first character that is not a letter
+// or on the end of the first line whose length differs from the first line.
+func Parse(txt string) (Grid, error) {
+	var g Grid
+	for _, line := range puzzle.Lines(txt) {
+		if line.Blank() {
+			continue
+		}
+		f := line.Fields()
+		if len(f) > 1 {
+			return nil, line.Error(puzzle.Field{Text: " ", Column: f[1].Column - 1}, ErrLetter)
+		}
+		v := f[0]
+		for i, ch := range []byte(v.Text) {
+			if ch|0x20 < 'a' || ch|0x20 > 'z' {
+				return nil, line.Error(puzzle.Field{Text: v.Text[i : i+1], Column: v.Column + i}, ErrLetter)
+			}
+		}
+		if len(g) > 0 && len(v.Text) != len(g[0]) {
+			w := min(len(v.Text), len(g[0]))
+			return nil, line.Error(puzzle.Field{Text: v.Text[w:], Column: v.Column + w}, ErrWidth)
+		}
+		g = append(g, strings.ToUpper(v.Text))
+	}
+	return g, nil
+}
+
+// at returns the letter at line l and column c, or 0 outside of the grid.
+func (g Grid) at(l, c int) byte {
+	if l < 0 || l >= len(g) || c < 0 || c >= len(g[l]) {
+		return 0
+	}
+	return g[l][c]
+}
+
+// directions are the 8 directions of the clock used by 01_1: 00:00, 01:30, 03:00 and so on.
+var directions = [8][2]int{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+
+// Part1 counts the occurrences of "XMAS" in the grid, in all 8 directions.
+func Part1(ctx context.Context, txt string) (int, error) {
+	g, err := Parse(txt)
+	if err != nil {
+		return 0, err
+	}
+	var r int
+	for l := range g {
+		for c := range g[l] {
+			if g[l][c] != 'X' {
+				continue
+			}
+			// Check if the "X" character can form the word "XMAS" in any of the 8 directions.
+			for _, d := range directions {
+				if g.at(l+d[0], c+d[1]) == 'M' && g.at(l+2*d[0], c+2*d[1]) == 'A' && g.at(l+3*d[0], c+3*d[1]) == 'S' {
+					r++
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// Part2 counts the "MAS" crosses of the grid: an "A" with "MAS" written on both of its diagonals, in any direction.
+func Part2(ctx context.Context, txt string) (int, error) {
+	g, err := Parse(txt)
+	if err != nil {
+		return 0, err
+	}
+	var r int
+	for l := range g {
+		for c := range g[l] {
+			if g[l][c] != 'A' {
+				continue
+			}
+			// Each diagonal must hold an "M" and an "S" on opposite corners.
+			d1 := string([]byte{g.at(l-1, c-1), g.at(l+1, c+1)})
+			d2 := string([]byte{g.at(l-1, c+1), g.at(l+1, c-1)})
+			if (d1 == "MS" || d1 == "SM") && (d2 == "MS" || d2 == "SM") {
+				r++
+			}
+		}
+	}
+	return r, nil
+}
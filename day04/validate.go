@@ -0,0 +1,41 @@
+package day04
+
+import (
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// Validate checks the puzzle input against the format of Day 4: a rectangular grid of letters.
+// Unlike Parse, it goes on after a problem and reports them all.
+//
+// txt: The content of the puzzle input.
+// Returns: A *puzzle.ParseError for each character that is not a letter and each line whose length differs
+// from the first line, or puzzle.ErrEmpty.
+func Validate(txt string) []error {
+	var errs []error
+	width := -1
+	for _, line := range puzzle.Lines(txt) {
+		if line.Blank() {
+			continue
+		}
+		v := strings.TrimSpace(line.Text)
+		col := strings.Index(line.Text, v) + 1
+		for i, ch := range []byte(v) {
+			if ch|0x20 < 'a' || ch|0x20 > 'z' {
+				errs = append(errs, line.Error(puzzle.Field{Text: v[i : i+1], Column: col + i}, ErrLetter))
+			}
+		}
+		if width < 0 {
+			width = len(v)
+		}
+		if len(v) != width {
+			w := min(len(v), width)
+			errs = append(errs, line.Error(puzzle.Field{Text: v[w:], Column: col + w}, ErrWidth))
+		}
+	}
+	if width < 0 {
+		errs = append(errs, puzzle.ErrEmpty)
+	}
+	return errs
+}
@@ -0,0 +1,41 @@
+package day05
+
+import "slices"
+
+// SwapFix reorders an update the way the attempt of 02_1 does, which is known to give a wrong answer
+// (see 02_1/DISCLAIMER.md). Every pair of pages is listed, the later page first, as a rule that the
+// update would violate. While such a rule exists, the two pages are swapped to follow it, but the scan
+// stops at the first pair that does not match a rule, so most updates are left partly out of order.
+//
+// Parameters:
+//
+//	update ([]int): The pages of the update. The slice is not modified.
+//
+// Returns:
+//
+//	[]int: The reordered copy of the update.
+//	bool: True if a page was swapped, i.e. the update was considered out of order.
+func (m Manual) SwapFix(update []int) ([]int, bool) {
+	u := slices.Clone(update)
+
+	// List every pair of pages, the later page first, as a rule that the update would violate.
+	var toCheck []Rule
+	for i := 0; i < len(u); i++ {
+		for j := i + 1; j < len(u); j++ {
+			toCheck = append(toCheck, Rule{Before: u[j], After: u[i]})
+		}
+	}
+
+	swapped := false
+	for _, c := range toCheck {
+		// Like 02_1, stop at the first pair that does not match a rule.
+		if !slices.Contains(m.Rules, c) {
+			break
+		}
+		// Swap the pages to match the rule.
+		swapped = true
+		a, b := slices.Index(u, c.After), slices.Index(u, c.Before)
+		u[a], u[b] = c.Before, c.After
+	}
+	return u, swapped
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+
+	"github.com/cl3mcg/aoc2024/day05"
+	"github.com/cl3mcg/aoc2024/logging"
+)
+
+// main generates a random Day 5 instance from a hidden total order and writes it in the puzzle input format.
+// The answers expected for both parts are printed on the standard error, so that the solvers can be
+// checked against inputs other than input.txt, e.g.:
+//
+//	go run . -seed 42 -o /tmp/day05.txt && (cd ../01_1 && go run . -input /tmp/day05.txt)
+func main() {
+	pages := flag.Int("pages", 49, "number of distinct pages in the hidden total order")
+	ratio := flag.Float64("rules", 1, "share (0 to 1) of the pair rules not needed by any update that are emitted anyway")
+	updates := flag.Int("updates", 200, "number of updates to create")
+	minLen := flag.Int("min", 5, "minimum number of pages in an update")
+	maxLen := flag.Int("max", 23, "maximum number of pages in an update")
+	shuffle := flag.Float64("shuffle", 0.5, "probability (0 to 1) that an update is shuffled out of order")
+	seed := flag.Uint64("seed", 1, "seed of the random generator")
+	out := flag.String("o", "", "file to write the instance to (default: standard output)")
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
+	// Seed the generator so that a failing instance can be reproduced.
+	rng := rand.New(rand.NewPCG(*seed, *seed))
+
+	inst, err := day05.Generate(rng, day05.GenConfig{
+		Pages:      *pages,
+		RuleRatio:  *ratio,
+		Updates:    *updates,
+		MinLen:     *minLen,
+		MaxLen:     *maxLen,
+		ShuffleOdd: *shuffle,
+	})
+	if err != nil {
+		logging.Fatal(logger, "Error generating the instance", "err", err)
+	}
+
+	// Write the instance to the requested destination.
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			logging.Fatal(logger, "Error creating the output file", "err", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := fmt.Fprint(w, inst.Manual); err != nil {
+		logging.Fatal(logger, "Error writing the instance", "err", err)
+	}
+
+	// Print the expected answers apart from the instance itself.
+	fmt.Fprintln(os.Stderr, "Expected result for part 1: ", inst.Part1)
+	fmt.Fprintln(os.Stderr, "Expected result for part 2: ", inst.Part2)
+}
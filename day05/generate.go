@@ -0,0 +1,136 @@
+package day05
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GenConfig describes the shape of a random instance created by Generate.
+type GenConfig struct {
+	Pages      int     // Pages is the number of distinct pages in the hidden total order.
+	RuleRatio  float64 // RuleRatio is the share (0 to 1) of the unused pair rules that are emitted anyway.
+	Updates    int     // Updates is the number of updates to create.
+	MinLen     int     // MinLen is the minimum number of pages in an update.
+	MaxLen     int     // MaxLen is the maximum number of pages in an update.
+	ShuffleOdd float64 // ShuffleOdd is the probability (0 to 1) that an update is shuffled out of order.
+}
+
+// Instance is a generated puzzle input along with the answers expected for both parts.
+type Instance struct {
+	Manual Manual // Manual holds the generated rules and updates.
+	Part1  int    // Part1 is the sum of the middle pages of the updates already in the right order.
+	Part2  int    // Part2 is the sum of the middle pages of the other updates, once correctly ordered.
+}
+
+// Generate creates a random instance from a hidden total order over cfg.Pages pages.
+// Every pair of pages printed together in some update gets its rule emitted, so that the correct order
+// of each update is unique, as in the real puzzle. The other pair rules are emitted with probability cfg.RuleRatio.
+// Update lengths are always odd so that the middle page is well defined.
+//
+// Parameters:
+//
+//	rng (*rand.Rand): The source of randomness, seeded by the caller to make instances reproducible.
+//	cfg (GenConfig): The shape of the instance.
+//
+// Returns:
+//
+//	Instance: The generated manual and its expected answers.
+//	error: An error if the configuration is inconsistent.
+func Generate(rng *rand.Rand, cfg GenConfig) (Instance, error) {
+	if cfg.MinLen < 1 || cfg.MaxLen < cfg.MinLen || cfg.MaxLen > cfg.Pages {
+		return Instance{}, fmt.Errorf("invalid update length range %d..%d for %d pages", cfg.MinLen, cfg.MaxLen, cfg.Pages)
+	}
+	if cfg.MinLen == cfg.MaxLen && cfg.MinLen%2 == 0 {
+		return Instance{}, errors.New("update length range must contain an odd length")
+	}
+
+	// Pick distinct page numbers and shuffle them: their order is the hidden total order.
+	// Two-digit pages are used when possible to look like the real puzzle input.
+	first := 10
+	if cfg.Pages > 90 {
+		first = 1
+	}
+	order := rng.Perm(cfg.Pages)
+	rank := make(map[int]int, cfg.Pages)
+	for i := range order {
+		order[i] += first
+		rank[order[i]] = i
+	}
+
+	var inst Instance
+	used := make(map[Rule]bool)
+
+	for range cfg.Updates {
+		// Pick an odd length in the configured range.
+		n := cfg.MinLen + rng.IntN(cfg.MaxLen-cfg.MinLen+1)
+		if n%2 == 0 {
+			if n < cfg.MaxLen {
+				n++
+			} else {
+				n--
+			}
+		}
+
+		// Pick n distinct pages and sort them according to the hidden order.
+		u := slices.Clone(order)
+		rng.Shuffle(len(u), func(i, j int) { u[i], u[j] = u[j], u[i] })
+		u = u[:n]
+		slices.SortFunc(u, func(a, b int) int { return rank[a] - rank[b] })
+
+		// Every pair of the update needs its rule so that the update has a single correct order.
+		for i := range u {
+			for j := i + 1; j < len(u); j++ {
+				used[Rule{Before: u[i], After: u[j]}] = true
+			}
+		}
+
+		// Shuffle some updates out of order. Shuffling is repeated until the order actually changes.
+		if n > 1 && rng.Float64() < cfg.ShuffleOdd {
+			sorted := slices.Clone(u)
+			for slices.Equal(u, sorted) {
+				rng.Shuffle(len(u), func(i, j int) { u[i], u[j] = u[j], u[i] })
+			}
+			inst.Part2 += Middle(sorted)
+		} else {
+			inst.Part1 += Middle(u)
+		}
+		inst.Manual.Updates = append(inst.Manual.Updates, u)
+	}
+
+	// Emit the rules needed by the updates, plus a share of the others.
+	for i := range order {
+		for j := i + 1; j < len(order); j++ {
+			r := Rule{Before: order[i], After: order[j]}
+			if used[r] || rng.Float64() < cfg.RuleRatio {
+				inst.Manual.Rules = append(inst.Manual.Rules, r)
+			}
+		}
+	}
+	rng.Shuffle(len(inst.Manual.Rules), func(i, j int) {
+		inst.Manual.Rules[i], inst.Manual.Rules[j] = inst.Manual.Rules[j], inst.Manual.Rules[i]
+	})
+
+	return inst, nil
+}
+
+// String formats the manual in the puzzle input format: the rules, a blank line, then the updates.
+func (m Manual) String() string {
+	var sb strings.Builder
+	for _, r := range m.Rules {
+		fmt.Fprintf(&sb, "%d|%d\n", r.Before, r.After)
+	}
+	sb.WriteString("\n")
+	for _, u := range m.Updates {
+		ps := make([]string, len(u))
+		for i, p := range u {
+			ps[i] = strconv.Itoa(p)
+		}
+		sb.WriteString(strings.Join(ps, ","))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
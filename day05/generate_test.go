@@ -0,0 +1,158 @@
+package day05
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// FuzzGenerate solves random instances and compares the answers with the ones expected by Generate.
+// Part 1 is checked with the registered solver and part 2, which is not solved yet, with sortUpdate.
+// The attempt of 02_1 at part 2 is run by TestSwapFix instead, as it is known to be wrong.
+// Run it with go test -fuzz FuzzGenerate ./day05.
+func FuzzGenerate(f *testing.F) {
+	f.Add(uint64(1), 49, 200, 5, 23, 1.0, 0.5) // The shape of the real puzzle input, as day05/gen does.
+	f.Add(uint64(2), 5, 10, 1, 5, 0.0, 0.5)    // Only the rules needed by the updates.
+	f.Add(uint64(3), 3, 20, 3, 3, 0.3, 1.0)    // Every update out of order.
+	f.Add(uint64(4), 120, 30, 1, 99, 0.1, 0.0) // One-digit pages, every update in order.
+
+	f.Fuzz(func(t *testing.T, seed uint64, pages, updates, minLen, maxLen int, ratio, shuffle float64) {
+		// Bring the fuzzed shape into a range that Generate accepts and that runs quickly.
+		cfg := GenConfig{
+			Pages:      1 + abs(pages)%150,
+			Updates:    abs(updates) % 100,
+			RuleRatio:  frac(ratio),
+			ShuffleOdd: frac(shuffle),
+		}
+		cfg.MinLen = 1 + abs(minLen)%cfg.Pages
+		cfg.MaxLen = cfg.MinLen + abs(maxLen)%(cfg.Pages-cfg.MinLen+1)
+		if cfg.MinLen == cfg.MaxLen && cfg.MinLen%2 == 0 {
+			cfg.MinLen--
+		}
+
+		inst, err := Generate(rand.New(rand.NewPCG(seed, seed)), cfg)
+		if err != nil {
+			t.Fatalf("Generate(%+v): %v", cfg, err)
+		}
+		txt := inst.Manual.String()
+
+		// The generated input must be well formed and read back as it was written. Validate also requires
+		// both sections, which a small instance may lack, e.g. when every update holds a single page.
+		if len(inst.Manual.Rules) > 0 && len(inst.Manual.Updates) > 0 {
+			if errs := Validate(txt); len(errs) > 0 {
+				t.Fatalf("Validate rejects the generated input: %v\n%s", errs, txt)
+			}
+		}
+		m, err := Parse(txt)
+		if err != nil {
+			t.Fatalf("Parse: %v\n%s", err, txt)
+		}
+		if !slices.Equal(m.Rules, inst.Manual.Rules) || !slices.EqualFunc(m.Updates, inst.Manual.Updates, slices.Equal) {
+			t.Fatalf("Parse(String()) differs from the generated manual\n%s", txt)
+		}
+
+		got, err := Part1(context.Background(), txt)
+		if err != nil {
+			t.Fatalf("Part1: %v", err)
+		}
+		if got != inst.Part1 {
+			t.Errorf("Part1 = %d, want %d\n%s", got, inst.Part1, txt)
+		}
+
+		var part2 int
+		for _, u := range m.Updates {
+			if len(m.Violations(u)) == 0 {
+				continue
+			}
+			sorted, err := sortUpdate(m, u)
+			if err != nil {
+				t.Fatalf("update %v: %v\n%s", u, err, txt)
+			}
+			if v := m.Violations(sorted); len(v) > 0 {
+				t.Fatalf("sorted update %v still violates %v", sorted, v)
+			}
+			part2 += Middle(sorted)
+		}
+		if part2 != inst.Part2 {
+			t.Errorf("part 2 = %d, want %d\n%s", part2, inst.Part2, txt)
+		}
+	})
+}
+
+// sortUpdate returns the pages of the update in the order given by the rules applying to it,
+// using a topological sort (Kahn's algorithm). It fails if the rules do not define a single order,
+// which Generate promises never to happen.
+func sortUpdate(m Manual, update []int) ([]int, error) {
+	// Count, for each page, the pages that must be printed before it.
+	before := make(map[int]int, len(update))
+	for _, p := range update {
+		before[p] = 0
+	}
+	rules := m.Subset(update)
+	for _, r := range rules {
+		before[r.After]++
+	}
+
+	var sorted []int
+	for len(sorted) < len(update) {
+		// Exactly one page must be free to print next for the order to be unique.
+		var free []int
+		for _, p := range update {
+			if n, ok := before[p]; ok && n == 0 {
+				free = append(free, p)
+			}
+		}
+		if len(free) != 1 {
+			return nil, fmt.Errorf("%d pages can be printed after %v", len(free), sorted)
+		}
+
+		p := free[0]
+		sorted = append(sorted, p)
+		delete(before, p)
+		for _, r := range rules {
+			if r.Before == p {
+				before[r.After]--
+			}
+		}
+	}
+	return sorted, nil
+}
+
+// abs returns the absolute value of n, mapping the lowest int to zero.
+func abs(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	return max(n, 0)
+}
+
+// frac brings a fuzzed float into the range 0 to 1, mapping NaN and infinities to zero.
+func frac(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0
+	}
+	return math.Abs(math.Mod(x, 1))
+}
+
+// TestSwapFix runs the attempt of 02_1 on an instance shaped like the real puzzle input. It is a known failure:
+// the generated instance exposes the early stop of the swaps, so the test is skipped with both answers.
+func TestSwapFix(t *testing.T) {
+	cfg := GenConfig{Pages: 49, RuleRatio: 1, Updates: 200, MinLen: 5, MaxLen: 23, ShuffleOdd: 0.5}
+	inst, err := Generate(rand.New(rand.NewPCG(1, 1)), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got int
+	for _, u := range inst.Manual.Updates {
+		if fixed, swapped := inst.Manual.SwapFix(u); swapped {
+			got += Middle(fixed)
+		}
+	}
+	if got != inst.Part2 {
+		t.Skipf("known failure: the attempt of 02_1 gives %d instead of %d, see 02_1/DISCLAIMER.md", got, inst.Part2)
+	}
+}
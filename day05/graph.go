@@ -0,0 +1,128 @@
+package day05
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Format identifies the graph description language used by WriteGraph.
+type Format string
+
+const (
+	FormatDOT     Format = "dot"     // FormatDOT renders the rules as a Graphviz DOT digraph.
+	FormatMermaid Format = "mermaid" // FormatMermaid renders the rules as a Mermaid flowchart.
+)
+
+// WriteGraph writes the page ordering rules as a directed graph, one edge "X -> Y" per rule "X|Y".
+// If update is nil, the whole rule set is rendered. Otherwise only the subgraph induced by the pages
+// of the update is rendered, and the edges violated by the update are highlighted in red.
+//
+// Parameters:
+//
+//	w (io.Writer): The destination of the graph description.
+//	m (Manual): The manual holding the rules to render.
+//	update ([]int): The update used to select and highlight the rules, or nil for all the rules.
+//	f (Format): The graph description language to use.
+//
+// Returns:
+//
+//	error: An error if the format is unknown or if writing to w fails.
+func WriteGraph(w io.Writer, m Manual, update []int, f Format) error {
+	// Select the rules to draw and the ones to highlight.
+	rules := m.Rules
+	var broken []Rule
+	if update != nil {
+		rules = m.Subset(update)
+		broken = m.Violations(update)
+	}
+
+	switch f {
+	case FormatDOT:
+		return writeDOT(w, rules, broken, update)
+	case FormatMermaid:
+		return writeMermaid(w, rules, broken, update)
+	}
+	return fmt.Errorf("unknown graph format %q", f)
+}
+
+// writeDOT renders the rules as a Graphviz DOT digraph.
+func writeDOT(w io.Writer, rules, broken []Rule, update []int) error {
+	ew := &errWriter{w: w}
+
+	ew.printf("digraph rules {\n")
+	ew.printf("\trankdir=LR;\n")
+
+	// Declare the pages of the update first so they keep their printing order in the output.
+	for i, p := range update {
+		ew.printf("\t%d [label=\"%d (#%d)\"];\n", p, p, i+1)
+	}
+
+	for _, r := range rules {
+		if slices.Contains(broken, r) {
+			ew.printf("\t%d -> %d [color=red, penwidth=2];\n", r.Before, r.After)
+			continue
+		}
+		ew.printf("\t%d -> %d;\n", r.Before, r.After)
+	}
+
+	ew.printf("}\n")
+	return ew.err
+}
+
+// writeMermaid renders the rules as a Mermaid flowchart.
+// Mermaid styles edges by their position, so the index of every violated edge is tracked.
+func writeMermaid(w io.Writer, rules, broken []Rule, update []int) error {
+	ew := &errWriter{w: w}
+
+	ew.printf("flowchart LR\n")
+
+	// Mermaid would label undeclared nodes with their identifier, so every page is declared.
+	if update != nil {
+		for i, p := range update {
+			ew.printf("\tp%d[\"%d (#%d)\"]\n", p, p, i+1)
+		}
+	} else {
+		for _, p := range pages(rules) {
+			ew.printf("\tp%d[\"%d\"]\n", p, p)
+		}
+	}
+
+	var highlighted []int
+	for i, r := range rules {
+		ew.printf("\tp%d --> p%d\n", r.Before, r.After)
+		if slices.Contains(broken, r) {
+			highlighted = append(highlighted, i)
+		}
+	}
+
+	for _, i := range highlighted {
+		ew.printf("\tlinkStyle %d stroke:red,stroke-width:2px\n", i)
+	}
+	return ew.err
+}
+
+// pages returns the sorted list of distinct pages referenced by the rules.
+func pages(rules []Rule) []int {
+	var ps []int
+	for _, r := range rules {
+		ps = append(ps, r.Before, r.After)
+	}
+	slices.Sort(ps)
+	return slices.Compact(ps)
+}
+
+// errWriter wraps an io.Writer and remembers the first error, so that a sequence of writes
+// can be checked once at the end.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// printf formats and writes to the underlying writer unless a previous write failed.
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
@@ -0,0 +1,129 @@
+// Package day05 holds the logic shared by the solutions of Day 5 (Print Queue).
+package day05
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// ErrRule is reported when a rule does not hold exactly two pages.
+var ErrRule = errors.New("expected two pages in rule")
+
+// Rule represents a page ordering rule written as "X|Y" in the puzzle input.
+// It means that if both pages are part of an update, Before must be printed at some point before After.
+type Rule struct {
+	Before int // Before is the page that must be printed first.
+	After  int // After is the page that must be printed later.
+}
+
+// Manual represents the whole puzzle input: the page ordering rules and the updates to produce.
+type Manual struct {
+	Rules   []Rule  // Rules is the list of page ordering rules, in input order.
+	Updates [][]int // Updates is the list of updates, each one being a list of page numbers.
+}
+
+// Parse converts the raw puzzle input into a Manual.
+// A line containing a "|" is a rule, any other non-empty line is an update.
+//
+// Parameters:
+//
+//	txt (string): The raw content of the puzzle input.
+//
+// Returns:
+//
+//	Manual: The parsed rules and updates.
+//	error: A *puzzle.ParseError on the first page number that cannot be converted to an int.
+func Parse(txt string) (Manual, error) {
+	var m Manual
+
+	// Process each line from the puzzle input.
+	for _, line := range puzzle.Lines(txt) {
+		if line.Blank() {
+			continue // Skip empty lines, including the one separating the two sections.
+		}
+
+		// If the line contains a rule (e.g., "47|53"), process it.
+		if strings.Contains(line.Text, "|") {
+			f := line.Split("|")
+			if len(f) != 2 {
+				return Manual{}, line.Error(f[2], ErrRule)
+			}
+			bi, err := line.Atoi(f[0], "invalid page number in rule")
+			if err != nil {
+				return Manual{}, err
+			}
+			ai, err := line.Atoi(f[1], "invalid page number in rule")
+			if err != nil {
+				return Manual{}, err
+			}
+			m.Rules = append(m.Rules, Rule{Before: bi, After: ai})
+			continue
+		}
+
+		// Otherwise the line contains an update (e.g., "75,47,61,53,29").
+		var u []int
+		for _, w := range line.Split(",") {
+			d, err := line.Atoi(w, "invalid page number in update")
+			if err != nil {
+				return Manual{}, err
+			}
+			u = append(u, d)
+		}
+		m.Updates = append(m.Updates, u)
+	}
+
+	return m, nil
+}
+
+// Violations returns the rules broken by the given update, in rule order.
+// A rule is broken when both of its pages are in the update and After is printed before Before.
+//
+// Parameters:
+//
+//	update ([]int): The list of pages of the update to check.
+//
+// Returns:
+//
+//	[]Rule: The rules that the update violates, or nil if the update is in the right order.
+func (m Manual) Violations(update []int) []Rule {
+	// Record the position of each page in the update.
+	pos := make(map[int]int, len(update))
+	for i, p := range update {
+		pos[p] = i
+	}
+
+	var broken []Rule
+	for _, r := range m.Rules {
+		bi, okB := pos[r.Before]
+		ai, okA := pos[r.After]
+		// Rules involving a page missing from the update are ignored.
+		if okB && okA && ai < bi {
+			broken = append(broken, r)
+		}
+	}
+	return broken
+}
+
+// Subset returns the rules whose both pages are part of the given update.
+// These are the only rules that apply to the update.
+func (m Manual) Subset(update []int) []Rule {
+	in := make(map[int]bool, len(update))
+	for _, p := range update {
+		in[p] = true
+	}
+
+	var rs []Rule
+	for _, r := range m.Rules {
+		if in[r.Before] && in[r.After] {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// Middle returns the middle page number of an update.
+func Middle(update []int) int {
+	return update[(len(update)-1)/2]
+}
@@ -0,0 +1,15 @@
+package day05
+
+import (
+	"testing"
+
+	"github.com/cl3mcg/aoc2024/example"
+)
+
+func TestPart1(t *testing.T) {
+	example.Check(t, 5, 1, Part1)
+}
+
+func TestPart2(t *testing.T) {
+	t.Skip("part 2 is not solved, see 02_1/DISCLAIMER.md")
+}
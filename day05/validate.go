@@ -0,0 +1,93 @@
+package day05
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// Errors reported by Validate, wrapped in a *puzzle.ParseError when they concern a line.
+var (
+	ErrSeparator = errors.New("update before the blank line separating the rules from the updates")
+	ErrLateRule  = errors.New("rule after the blank line separating the rules from the updates")
+	ErrEven      = errors.New("update with an even number of pages has no middle page")
+	ErrRepeated  = errors.New("page repeated in the update")
+	ErrNoRules   = errors.New("no page ordering rule in the input")
+	ErrNoUpdates = errors.New("no update in the input")
+)
+
+// Validate checks the puzzle input against the format of Day 5: the rules "X|Y", a blank line,
+// then the updates "A,B,C" with an odd number of distinct pages. Unlike Parse, it goes on after
+// a problem and reports them all.
+//
+// Parameters:
+//
+//	txt (string): The raw content of the puzzle input.
+//
+// Returns:
+//
+//	[]error: A *puzzle.ParseError for each invalid page number, malformed rule, misplaced line
+//	or update without a middle page, and ErrNoRules or ErrNoUpdates for a missing section.
+func Validate(txt string) []error {
+	var errs []error
+	rules, updates := 0, 0
+	inUpdates := false // inUpdates is true after the blank line separating the two sections.
+
+	for _, line := range puzzle.Lines(txt) {
+		if line.Blank() {
+			// The blank line only separates the sections once there are rules.
+			inUpdates = inUpdates || rules > 0
+			continue
+		}
+
+		if strings.Contains(line.Text, "|") {
+			if inUpdates {
+				errs = append(errs, line.Error(line.Fields()[0], ErrLateRule))
+			}
+			f := line.Split("|")
+			if len(f) != 2 {
+				errs = append(errs, line.Error(f[2], ErrRule))
+			}
+			for _, w := range f[:min(len(f), 2)] {
+				if _, err := line.Atoi(w, "invalid page number in rule"); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			rules++
+			continue
+		}
+
+		if !inUpdates {
+			// Report the missing separator once, then read the rest as updates.
+			errs = append(errs, line.Error(line.Fields()[0], ErrSeparator))
+			inUpdates = true
+		}
+		f := line.Split(",")
+		var pages []int
+		for _, w := range f {
+			p, err := line.Atoi(w, "invalid page number in update")
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if slices.Contains(pages, p) {
+				errs = append(errs, line.Error(w, ErrRepeated))
+			}
+			pages = append(pages, p)
+		}
+		if len(f)%2 == 0 {
+			errs = append(errs, line.Error(puzzle.Field{Text: strings.TrimSpace(line.Text), Column: f[0].Column}, ErrEven))
+		}
+		updates++
+	}
+
+	if rules == 0 {
+		errs = append(errs, ErrNoRules)
+	}
+	if updates == 0 {
+		errs = append(errs, ErrNoUpdates)
+	}
+	return errs
+}
@@ -2,11 +2,14 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"os"
 	"slices"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day06"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the content of a file and returns it as a string.
@@ -146,27 +149,29 @@ var points []Coord
 //
 //	None
 func main() {
+	// Log to the standard error, with the level and format chosen by -v, -q and -log-format.
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
 	// Read the puzzle input from the file "input.txt".
 	// If there's an error retrieving the input, log the error and stop execution.
 	txt, err := retrievePuzzleInput("../input.txt")
 	if err != nil {
 		// Log a fatal error and terminate the program if the input file cannot be read.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input to clean it.
-	txt = strings.TrimSpace(txt)
-
-	var coords [][]string
-
-	// Split the input text into lines based on newline characters.
-	yRaw := strings.Split(txt, "\n")
-	for _, v := range yRaw {
-		v = strings.TrimSpace(v)
-		p := strings.Split(v, "")
-		coords = append(coords, p)
+	// Parse the map of the lab, stopping on a malformed input with its position.
+	lab, err := day06.Parse(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, "../input.txt"))
+		os.Exit(1)
 	}
 
+	// Take the cells of the map with the bottom line first, so that Y grows upwards.
+	coords := lab.Cells()
 	slices.Reverse(coords)
 
 	for i, y := range coords {
@@ -181,15 +186,15 @@ func main() {
 				pt.Dir = y[j]
 			}
 			points = append(points, pt)
-			fmt.Printf("points is appended pt with values %v: pt X = %v, pt Y = %v\n", pt, pt.X, pt.Y)
+			logger.Debug("point appended", "point", pt, "x", pt.X, "y", pt.Y)
 		}
 	}
 
 	startPoint, err := spotFinder(points)
 	if err != nil {
-		log.Fatalf("Error finding startPoint spot: %v", err)
+		logging.Fatal(logger, "Error finding startPoint spot", "err", err)
 	}
-	fmt.Printf("startPoint data : x is %v, y is %v, dir is %v\n", startPoint.X, startPoint.Y, startPoint.Dir)
+	logger.Debug("start point", "x", startPoint.X, "y", startPoint.Y, "dir", startPoint.Dir)
 
 	// Iterate through the moves until an error is thrown
 	currentPoint := startPoint
@@ -197,12 +202,12 @@ func main() {
 		endPoint, err := move(&currentPoint) // Perform the move
 		if err != nil {
 			// If an error occurs, break the loop
-			fmt.Printf("Error occurred: %v\n", err)
+			logger.Debug("guard stopped", "err", err)
 			break
 		}
 
 		// Log the successful move
-		fmt.Printf("Moved to endPoint: x = %v, y = %v, dir = %v\n", endPoint.X, endPoint.Y, endPoint.Dir)
+		logger.Debug("guard moved", "x", endPoint.X, "y", endPoint.Y, "dir", endPoint.Dir)
 		// Update currentPoint with the new position after the move
 		currentPoint = endPoint
 	}
@@ -219,6 +224,6 @@ func main() {
 	// Print the final sum of all valid middle page numbers.
 	fmt.Println("The result 'r' should be: ", r)
 
-	fmt.Println("✨ Gracefully exiting the program...")
+	logger.Debug("exiting")
 	os.Exit(0)
 }
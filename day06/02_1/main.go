@@ -2,11 +2,14 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"os"
 	"slices"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day06"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the content of a file and returns it as a string.
@@ -146,27 +149,29 @@ var points []Coord
 //
 //	None
 func main() {
+	// Log to the standard error, with the level and format chosen by -v, -q and -log-format.
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
 	// Read the puzzle input from the file "input.txt".
 	// If there's an error retrieving the input, log the error and stop execution.
 	txt, err := retrievePuzzleInput("../input.txt")
 	if err != nil {
 		// Log a fatal error and terminate the program if the input file cannot be read.
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input to clean it.
-	txt = strings.TrimSpace(txt)
-
-	var coords [][]string
-
-	// Split the input text into lines based on newline characters.
-	yRaw := strings.Split(txt, "\n")
-	for _, v := range yRaw {
-		v = strings.TrimSpace(v)
-		p := strings.Split(v, "")
-		coords = append(coords, p)
+	// Parse the map of the lab, stopping on a malformed input with its position.
+	lab, err := day06.Parse(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, "../input.txt"))
+		os.Exit(1)
 	}
 
+	// Take the cells of the map with the bottom line first, so that Y grows upwards.
+	coords := lab.Cells()
 	slices.Reverse(coords)
 
 	for i, y := range coords {
@@ -186,21 +191,21 @@ func main() {
 
 	startPoint, err := spotFinder(points)
 	if err != nil {
-		log.Fatalf("Error finding startPoint spot: %v", err)
+		logging.Fatal(logger, "Error finding startPoint spot", "err", err)
 	}
-	fmt.Printf("startPoint data : x is %v, y is %v, dir is %v\n", startPoint.X, startPoint.Y, startPoint.Dir)
+	logger.Debug("start point", "x", startPoint.X, "y", startPoint.Y, "dir", startPoint.Dir)
 
 	// Storing the result in a variable.
 	var r int
 
 	for _, pt := range points {
 		if pt == startPoint || pt.IsBlocked {
-			fmt.Printf("▶️ Bypass blocked point %v\n", pt)
+			logger.Debug("point skipped", "point", pt)
 			continue
 		}
 
 		pt.IsBlocked = true
-		fmt.Printf("⚠️ Now blocking %v\n", pt)
+		logger.Debug("point blocked", "point", pt)
 
 		// Reset the guard's path
 		for i := range points {
@@ -217,7 +222,7 @@ func main() {
 			if err != nil {
 				// If an error occurs, break the loop
 				pt.IsBlocked = false
-				fmt.Printf("Error occurred: %v\n", err)
+				logger.Debug("guard stopped", "err", err)
 				break
 			}
 
@@ -226,7 +231,7 @@ func main() {
 
 			// If the guard revisits the same point with the same direction, they are stuck in a loop
 			if visited[visitedKey] {
-				fmt.Printf("✅ Match at %v********************************************\n", pt)
+				logger.Debug("loop found", "point", pt)
 				r++
 				pt.IsBlocked = false
 				break
@@ -241,6 +246,6 @@ func main() {
 	// Print the final sum of all valid middle page numbers.
 	fmt.Println("The result 'r' should be: ", r)
 
-	fmt.Println("✨ Gracefully exiting the program...")
+	logger.Debug("exiting")
 	os.Exit(0)
 }
@@ -2,11 +2,15 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
-	"log"
 	"os"
 	"slices"
-	"strings"
+
+	"github.com/cl3mcg/aoc2024/day06"
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/progress"
+	"github.com/cl3mcg/aoc2024/puzzle"
 )
 
 // retrievePuzzleInput reads the content of a file and returns it as a string.
@@ -180,48 +184,57 @@ func createPlan(coords [][]string) {
 // main is the entry point of the program.
 // It initializes the grid, finds the start point, and computes the result based on the movement rules.
 func main() {
+	// Log to the standard error, with the level and format chosen by -v, -q and -log-format.
+	logf := logging.NewFlags(flag.CommandLine)
+	flag.Parse()
+	logger := logf.Logger(os.Stderr)
+
 	// Read the puzzle input from the file "input.txt".
 	// If there's an error retrieving the input, log the error and stop execution.
 	txt, err := retrievePuzzleInput("../input.txt")
 	if err != nil {
-		log.Fatalf("Error retrieving the puzzle input: %v", err)
+		logging.Fatal(logger, "Error retrieving the puzzle input", "err", err)
 	}
 
-	// Trim any leading or trailing whitespace characters from the input to clean it.
-	txt = strings.TrimSpace(txt)
-
-	var coords [][]string
-
-	// Split the input text into lines based on newline characters.
-	yRaw := strings.Split(txt, "\n")
-	for _, v := range yRaw {
-		v = strings.TrimSpace(v)
-		p := strings.Split(v, "")
-		coords = append(coords, p)
+	// Parse the map of the lab, stopping on a malformed input with its position.
+	lab, err := day06.Parse(txt)
+	if err != nil {
+		// Show the offending line with a caret under the bad token, like a compiler error.
+		puzzle.Render(os.Stderr, puzzle.WithFile(err, "../input.txt"))
+		os.Exit(1)
 	}
 
+	// Take the cells of the map with the bottom line first, so that Y grows upwards.
+	coords := lab.Cells()
 	slices.Reverse(coords)
 
 	createPlan(coords)
 
 	startPoint, err := spotFinder(points)
 	if err != nil {
-		log.Fatalf("Error finding startPoint spot: %v", err)
+		logging.Fatal(logger, "Error finding startPoint spot", "err", err)
 	}
-	fmt.Printf("startPoint data : x is %v, y is %v, dir is %v\n", startPoint.X, startPoint.Y, startPoint.Dir)
+	logger.Debug("start point", "x", startPoint.X, "y", startPoint.Y, "dir", startPoint.Dir)
 
 	// Starting the result
 	r := 0
 
+	// Report the progress on the standard error, one unit per point, so that only the answer goes to the standard output.
+	pr := progress.New()
+	pr.SetTotal(len(points))
+	stop := progress.Show(os.Stderr, logger, pr, "day 06 part 2")
+
 	// Loop over each point of the plan
 	for i, p := range points {
+		pr.Add(1)
+
 		// If the point is blocked initially or if the point is the starting point, no need to loop.
 		if !p.IsBlocked && !(p.X == startPoint.X && p.Y == startPoint.Y) {
 
 			// Manually switch and block the point to see if this creates the guard to be in an endless path loop.
 			points[i].IsBlocked = true
 
-			fmt.Printf("Current value of r: %v | Checking for mutated blocked p with coodinates: %v %v\n", r, p.X, p.Y)
+			pr.Note(fmt.Sprintf("%d loops found, checking %v,%v", r, p.X, p.Y))
 
 			// Initialize a slice to register the points walked on and their direction.
 			var visited []string
@@ -258,9 +271,11 @@ func main() {
 		}
 	}
 
+	stop()
+
 	// Print the final sum of all valid middle page numbers.
 	fmt.Println("The result 'r' should be: ", r)
 
-	fmt.Println("✨ Gracefully exiting the program...")
+	logger.Debug("exiting")
 	os.Exit(0)
 }
@@ -0,0 +1,177 @@
+// Package day06 holds the logic of Day 6 (Guard Gallivant), shared with the aoc runner.
+package day06
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/logging"
+	"github.com/cl3mcg/aoc2024/progress"
+	"github.com/cl3mcg/aoc2024/puzzle"
+	"github.com/cl3mcg/aoc2024/runner"
+)
+
+func init() {
+	runner.Register(6, 1, Part1)
+	runner.Register(6, 2, Part2)
+	runner.RegisterValidator(6, Validate)
+}
+
+// Lab is the map of the lab, with the position and direction of the guard.
+type Lab struct {
+	Width, Height int
+	Blocked       []bool // Blocked tells for each cell, indexed by y*Width+x, whether it holds an obstruction.
+	X, Y, Dir     int    // X, Y and Dir are the starting position and direction of the guard.
+}
+
+// moves are the steps of the guard in each direction, turning right from one to the next: up, right, down, left.
+var moves = [4][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// Errors reported by Parse. ErrCell, ErrWidth and ErrGuards are wrapped in a *puzzle.ParseError.
+var (
+	ErrCell    = errors.New("expected '.', '#' or the guard '^', '>', 'v' or '<'")
+	ErrWidth   = errors.New("the lines of the map do not have the same length")
+	ErrGuards  = errors.New("second guard on the map")
+	ErrNoGuard = errors.New("no guard found on the map")
+)
+
+// Parse reads the map of the lab. The guard is the only cell showing a direction: ^, >, v or <.
+//
+// txt: The content of the puzzle input.
+// Returns: The lab, a *puzzle.ParseError on the first unexpected cell, on the end of the first line
+// whose length differs from the first line or on a second guard, or ErrNoGuard.
+func Parse(txt string) (Lab, error) {
+	var lab Lab
+	found := false
+	for _, line := range puzzle.Lines(txt) {
+		if line.Blank() {
+			continue
+		}
+		v := strings.TrimSpace(line.Text)
+		col := strings.Index(line.Text, v) + 1
+		if lab.Width == 0 {
+			lab.Width = len(v)
+		}
+		for x, ch := range []byte(v) {
+			cell := puzzle.Field{Text: v[x : x+1], Column: col + x}
+			if x == lab.Width {
+				return Lab{}, line.Error(puzzle.Field{Text: v[x:], Column: col + x}, ErrWidth)
+			}
+			d := strings.IndexByte("^>v<", ch)
+			switch {
+			case d >= 0 && found:
+				return Lab{}, line.Error(cell, ErrGuards)
+			case d >= 0:
+				lab.X, lab.Y, lab.Dir = x, lab.Height, d
+				found = true
+			case ch != '.' && ch != '#':
+				return Lab{}, line.Error(cell, ErrCell)
+			}
+			lab.Blocked = append(lab.Blocked, ch == '#')
+		}
+		if len(v) < lab.Width {
+			return Lab{}, line.Error(line.End(), ErrWidth)
+		}
+		lab.Height++
+	}
+	if !found {
+		return Lab{}, ErrNoGuard
+	}
+	return lab, nil
+}
+
+// Cells returns the map as one string per cell, line by line from the top: "#" for an obstruction,
+// "." for an empty cell, and "^", ">", "v" or "<" for the guard.
+func (lab Lab) Cells() [][]string {
+	cells := make([][]string, lab.Height)
+	for y := range cells {
+		cells[y] = make([]string, lab.Width)
+		for x := range cells[y] {
+			switch {
+			case x == lab.X && y == lab.Y:
+				cells[y][x] = "^>v<"[lab.Dir : lab.Dir+1]
+			case lab.Blocked[y*lab.Width+x]:
+				cells[y][x] = "#"
+			default:
+				cells[y][x] = "."
+			}
+		}
+	}
+	return cells
+}
+
+// walk moves the guard until it leaves the map or walks in a loop.
+// visit, if not nil, is called with every cell the guard stands on, the starting cell included.
+// It returns true if the guard walks in a loop, i.e. reaches the same cell in the same direction twice.
+func (lab Lab) walk(visit func(i int)) bool {
+	x, y, d := lab.X, lab.Y, lab.Dir
+	seen := make([]uint8, len(lab.Blocked)) // seen holds one bit per direction for each cell.
+	for {
+		i := y*lab.Width + x
+		if seen[i]&(1<<d) != 0 {
+			return true
+		}
+		seen[i] |= 1 << d
+		if visit != nil {
+			visit(i)
+		}
+
+		nx, ny := x+moves[d][0], y+moves[d][1]
+		if nx < 0 || nx >= lab.Width || ny < 0 || ny >= lab.Height {
+			return false
+		}
+		if lab.Blocked[ny*lab.Width+nx] {
+			// Turn right instead of moving.
+			d = (d + 1) % 4
+			continue
+		}
+		x, y = nx, ny
+	}
+}
+
+// Part1 counts the distinct cells visited by the guard before leaving the map.
+func Part1(ctx context.Context, txt string) (int, error) {
+	lab, err := Parse(txt)
+	if err != nil {
+		return 0, err
+	}
+	walked := make(map[int]bool)
+	lab.walk(func(i int) { walked[i] = true })
+	return len(walked), nil
+}
+
+// Part2 counts the cells where a new obstruction would trap the guard in a loop.
+// Like 02_2, every free cell other than the starting cell is blocked in turn and the guard walks again.
+// This is the slow part, so it reports its progress into ctx, one unit per cell,
+// and stops between two cells when ctx is done, telling how far it went.
+func Part2(ctx context.Context, txt string) (int, error) {
+	lab, err := Parse(txt)
+	if err != nil {
+		return 0, err
+	}
+	start := lab.Y*lab.Width + lab.X
+	pr := progress.FromContext(ctx)
+	pr.SetTotal(len(lab.Blocked))
+	logger := logging.FromContext(ctx)
+
+	var r int
+	for i, b := range lab.Blocked {
+		pr.Add(1)
+		if err := ctx.Err(); err != nil {
+			return r, fmt.Errorf("stopped after %d of %d cells with %d loops found: %w", i, len(lab.Blocked), r, err)
+		}
+		if b || i == start {
+			continue
+		}
+		lab.Blocked[i] = true
+		if lab.walk(nil) {
+			r++
+			pr.Note(fmt.Sprintf("%d loops found", r))
+			logger.Debug("obstruction traps the guard", "x", i%lab.Width, "y", i/lab.Width)
+		}
+		lab.Blocked[i] = false
+	}
+	return r, nil
+}
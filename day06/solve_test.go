@@ -0,0 +1,15 @@
+package day06
+
+import (
+	"testing"
+
+	"github.com/cl3mcg/aoc2024/example"
+)
+
+func TestPart1(t *testing.T) {
+	example.Check(t, 6, 1, Part1)
+}
+
+func TestPart2(t *testing.T) {
+	example.Check(t, 6, 2, Part2)
+}
@@ -0,0 +1,51 @@
+package day06
+
+import (
+	"strings"
+
+	"github.com/cl3mcg/aoc2024/puzzle"
+)
+
+// Validate checks the puzzle input against the format of Day 6: a rectangular map of '.' and '#'
+// with exactly one guard. Unlike Parse, it goes on after a problem and reports them all.
+//
+// txt: The content of the puzzle input.
+// Returns: A *puzzle.ParseError for each unexpected cell, each line whose length differs from the first line
+// and each guard after the first one, ErrNoGuard if there is no guard, or puzzle.ErrEmpty.
+func Validate(txt string) []error {
+	var errs []error
+	width, guards := -1, 0
+	for _, line := range puzzle.Lines(txt) {
+		if line.Blank() {
+			continue
+		}
+		v := strings.TrimSpace(line.Text)
+		col := strings.Index(line.Text, v) + 1
+		for x, ch := range []byte(v) {
+			cell := puzzle.Field{Text: v[x : x+1], Column: col + x}
+			switch {
+			case strings.IndexByte("^>v<", ch) >= 0:
+				guards++
+				if guards > 1 {
+					errs = append(errs, line.Error(cell, ErrGuards))
+				}
+			case ch != '.' && ch != '#':
+				errs = append(errs, line.Error(cell, ErrCell))
+			}
+		}
+		if width < 0 {
+			width = len(v)
+		}
+		if len(v) != width {
+			w := min(len(v), width)
+			errs = append(errs, line.Error(puzzle.Field{Text: v[w:], Column: col + w}, ErrWidth))
+		}
+	}
+	switch {
+	case width < 0:
+		errs = append(errs, puzzle.ErrEmpty)
+	case guards == 0:
+		errs = append(errs, ErrNoGuard)
+	}
+	return errs
+}
@@ -0,0 +1,36 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Check solves the example of a part, extracted into testdata by "aoc examples", and compares the answer.
+// It is meant for the solve_test.go file of a day, whose tests run in the dayXX directory.
+// The test is skipped if the example has not been extracted yet.
+//
+// t: The test of the part.
+// day: The day of the puzzle, from 1 to 25.
+// part: The part whose example is solved, 1 or 2.
+// solve: The solver of the part, whose answer is compared in its fmt.Sprint form.
+func Check[T any](t *testing.T, day, part int, solve func(context.Context, string) (T, error)) {
+	t.Helper()
+	ex, err := Load("..", day, part)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skip("no example yet: paste the puzzle description into the README.md and run aoc examples")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := solve(context.Background(), ex.Input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != ex.Answer {
+		t.Errorf("part %d of the example = %v, want %s", part, got, ex.Answer)
+	}
+}
@@ -0,0 +1,238 @@
+// Package example extracts the example input and the expected example answer of a part
+// from the puzzle description kept in the README.md of its NN_N directory.
+//
+// The heuristic looks at the section of the part ("### Part One" or "### Part Two"):
+// the example input is the first code block introduced by a paragraph mentioning an example,
+// and the expected answer is the last emphasized code span of the section, such as _`18`_.
+// Part two often reuses the example of part one, which is then taken from the first section.
+//
+// When the heuristic picks the wrong block or the wrong answer, HTML comments in the README
+// override it (with the default prefix):
+//
+//	<!-- aoc:example -->  the next code block is the example input of the section
+//	<!-- aoc:answer 42 --> the expected answer of the section is 42
+package example
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultPrefix is the prefix of the marker comments.
+const DefaultPrefix = "aoc:"
+
+// ErrNoExample is returned when a description holds no example input or no example answer.
+var ErrNoExample = errors.New("no example found")
+
+// Example is the example of a part, as given in its description.
+type Example struct {
+	Part   int    // Part is 1 or 2.
+	Input  string // Input is the example input, ending with a newline like the real inputs.
+	Answer string // Answer is the expected answer for the example input.
+}
+
+// block is a paragraph of text or a code block of the description.
+type block struct {
+	code   bool   // code is true for indented or fenced code blocks.
+	text   string // text is the content, without the indentation or the fences of code blocks.
+	marked bool   // marked is true if the block follows an example marker.
+}
+
+// answerRe matches an emphasized code span, the way the puzzle descriptions highlight the answers: _`18`_ or *`18`*.
+var answerRe = regexp.MustCompile("[_*]`([^`]+)`[_*]")
+
+// Extract finds the example of a part in a puzzle description.
+//
+// md: The markdown description, holding the section of part one and, for part two, the section of part two.
+// part: The part whose example is wanted, 1 or 2.
+// prefix: The prefix of the marker comments, usually DefaultPrefix.
+// Returns: The example, or an error wrapping ErrNoExample if the description has no section for the part,
+// no example input or no answer.
+func Extract(md string, part int, prefix string) (Example, error) {
+	sections := split(md, prefix)
+	if part < 1 || part > len(sections) {
+		return Example{}, fmt.Errorf("part %d: %w: no section for the part", part, ErrNoExample)
+	}
+	sec := sections[part-1]
+	ex := Example{Part: part}
+
+	// The answer: an explicit marker, or the last highlighted value of the section.
+	answerMarker := regexp.MustCompile(`<!--\s*` + regexp.QuoteMeta(prefix) + `answer\s+(.*?)\s*-->`)
+	for _, b := range sec {
+		if b.code {
+			continue
+		}
+		if m := answerMarker.FindStringSubmatch(b.text); m != nil {
+			ex.Answer = m[1]
+			break
+		}
+		if ms := answerRe.FindAllStringSubmatch(b.text, -1); ms != nil {
+			ex.Answer = ms[len(ms)-1][1]
+		}
+	}
+	if ex.Answer == "" {
+		return Example{}, fmt.Errorf("part %d: %w: no answer", part, ErrNoExample)
+	}
+
+	// The input: a marked block, or the first block introduced as an example, in this section first.
+	for i := part - 1; i >= 0; i-- {
+		if in, ok := input(sections[i]); ok {
+			ex.Input = in
+			return ex, nil
+		}
+	}
+	return Example{}, fmt.Errorf("part %d: %w: no example input", part, ErrNoExample)
+}
+
+// input finds the example input of a section. It reports false if the section has none of its own.
+func input(sec []block) (string, bool) {
+	for _, b := range sec {
+		if b.code && b.marked {
+			return b.text, true
+		}
+	}
+	for i, b := range sec {
+		if !b.code || i == 0 || sec[i-1].code {
+			continue
+		}
+		intro := strings.ToLower(sec[i-1].text)
+		if strings.Contains(intro, "same example") {
+			// The block is the example of an earlier part, redrawn: use the original one.
+			return "", false
+		}
+		if strings.Contains(intro, "example") || strings.Contains(intro, "for instance") {
+			return b.text, true
+		}
+	}
+	return "", false
+}
+
+// split cuts the description into the blocks of each "### Part" section, in order.
+// The example marker is resolved here since it concerns the next block, the answer marker is left to Extract.
+func split(md, prefix string) [][]block {
+	exampleMarker := "<!-- " + prefix + "example -->"
+	var (
+		sections [][]block
+		cur      []block
+		para     []string
+		code     []string
+		fenced   bool
+		marked   bool
+		in       bool // in is true once the first section has started.
+	)
+	flushPara := func() {
+		if len(para) > 0 && in {
+			cur = append(cur, block{text: strings.Join(para, "\n")})
+		}
+		para = nil
+	}
+	flushCode := func() {
+		// Blank lines at the end of an indented block belong to the text that follows.
+		for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+			code = code[:len(code)-1]
+		}
+		if len(code) > 0 && in {
+			cur = append(cur, block{code: true, text: strings.Join(code, "\n") + "\n", marked: marked})
+			marked = false
+		}
+		code = nil
+	}
+
+	for _, l := range strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n") {
+		switch {
+		case fenced:
+			if strings.HasPrefix(l, "```") {
+				fenced = false
+				flushCode()
+				continue
+			}
+			code = append(code, l)
+		case strings.HasPrefix(l, "```"):
+			flushPara()
+			fenced = true
+		case strings.HasPrefix(l, "    ") || strings.HasPrefix(l, "\t"):
+			if len(para) > 0 && code == nil {
+				// An indented line right after a paragraph is a continuation, not a code block.
+				if strings.TrimSpace(l) != "" {
+					para = append(para, strings.TrimSpace(l))
+				}
+				continue
+			}
+			if l[0] == '\t' {
+				code = append(code, l[1:])
+			} else {
+				code = append(code, l[4:])
+			}
+		case strings.TrimSpace(l) == "":
+			if code != nil {
+				// A blank line may separate two parts of the same indented block.
+				code = append(code, "")
+				continue
+			}
+			flushPara()
+		default:
+			flushCode()
+			if strings.HasPrefix(l, "### Part") {
+				flushPara()
+				if in {
+					sections = append(sections, cur)
+				}
+				cur, in = nil, true
+				continue
+			}
+			if strings.Join(strings.Fields(l), " ") == exampleMarker {
+				flushPara()
+				marked = true
+				continue
+			}
+			para = append(para, l)
+		}
+	}
+	flushCode()
+	flushPara()
+	if in {
+		sections = append(sections, cur)
+	}
+	return sections
+}
+
+// Path returns the path of the testdata file holding the example input of a part: root/dayXX/testdata/partN.txt.
+// The expected answer is kept next to it, in partN.answer.
+func Path(root string, day, part int) string {
+	return filepath.Join(root, fmt.Sprintf("day%02d", day), "testdata", fmt.Sprintf("part%d.txt", part))
+}
+
+// AnswerPath returns the path of the testdata file holding the expected answer of the example of a part.
+func AnswerPath(root string, day, part int) string {
+	return strings.TrimSuffix(Path(root, day, part), ".txt") + ".answer"
+}
+
+// Write saves the example of a part into the testdata directory of its day.
+func Write(root string, day int, ex Example) error {
+	p := Path(root, day, ex.Part)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(p, []byte(ex.Input), 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(AnswerPath(root, day, ex.Part), []byte(ex.Answer+"\n"), 0o644)
+}
+
+// Load reads the example of a part from the testdata directory of its day.
+// The error wraps os.ErrNotExist if the example has not been extracted.
+func Load(root string, day, part int) (Example, error) {
+	in, err := os.ReadFile(Path(root, day, part))
+	if err != nil {
+		return Example{}, err
+	}
+	ans, err := os.ReadFile(AnswerPath(root, day, part))
+	if err != nil {
+		return Example{}, err
+	}
+	return Example{Part: part, Input: string(in), Answer: strings.TrimSpace(string(ans))}, nil
+}
@@ -0,0 +1,81 @@
+package example
+
+import (
+	"errors"
+	"testing"
+)
+
+// The descriptions below follow the layout of the puzzle READMEs: a "### Part" heading per part,
+// paragraphs introducing the example and an emphasized answer at the end of the section.
+const (
+	fenced = "# Day 1\n\n### Part One\n\nSome story.\n\nFor example:\n\n```\n3   4\n4   3\n```\n\nThe total is _`11`_.\n"
+
+	indented = "### Part One\n\nFor example:\n\n    3   4\n\n    4   3\n\nThe total is *`11`*.\n"
+
+	// The first example block is only an excerpt, the marked one is the full example.
+	marked = "### Part One\n\nFor example, a single line: `3   4`\n\n```\n3   4\n```\n\n<!-- aoc:example -->\n```\n3   4\n4   3\n```\n\nWith _`7`_ for the first line, the total is _`11`_.\n"
+
+	// The answer marker wins over the highlighted values.
+	answered = "### Part One\n\nFor example:\n\n```\n3   4\n```\n\nThe first pair gives _`1`_.\n<!-- aoc:answer 11 -->\n"
+
+	// The markers of another prefix are ignored.
+	prefixed = "### Part One\n\nFor example:\n\n```\n1\n```\n\n<!-- my:example -->\n```\n3   4\n4   3\n```\n\n<!-- aoc:answer 1 -->\nThe total is _`2`_.\n<!-- my:answer 11 -->\n"
+
+	// Part two redraws the example of part one, which is then taken from the first section.
+	same = "### Part One\n\nFor example:\n\n```\n3   4\n4   3\n```\n\nThe total is _`11`_.\n\n### Part Two\n\nHere is the same example list again:\n\n```\n3   4\n```\n\nThe similarity score is _`31`_.\n"
+
+	// Part two has no example of its own at all.
+	reused = "### Part One\n\nFor instance:\n\n```\n3   4\n4   3\n```\n\nThe total is _`11`_.\n\n### Part Two\n\nThe similarity score is _`31`_.\n"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name   string
+		md     string
+		part   int
+		prefix string
+		input  string
+		answer string
+	}{
+		{"fenced block", fenced, 1, DefaultPrefix, "3   4\n4   3\n", "11"},
+		{"indented block", indented, 1, DefaultPrefix, "3   4\n\n4   3\n", "11"},
+		{"example marker", marked, 1, DefaultPrefix, "3   4\n4   3\n", "11"},
+		{"answer marker", answered, 1, DefaultPrefix, "3   4\n", "11"},
+		{"other prefix", prefixed, 1, "my:", "3   4\n4   3\n", "11"},
+		{"default prefix ignores the others", prefixed, 1, DefaultPrefix, "1\n", "1"},
+		{"same example", same, 2, DefaultPrefix, "3   4\n4   3\n", "31"},
+		{"no example in part two", reused, 2, DefaultPrefix, "3   4\n4   3\n", "31"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ex, err := Extract(tc.md, tc.part, tc.prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ex.Part != tc.part || ex.Input != tc.input || ex.Answer != tc.answer {
+				t.Errorf("Extract = part %d, input %q, answer %q, want part %d, input %q, answer %q",
+					ex.Part, ex.Input, ex.Answer, tc.part, tc.input, tc.answer)
+			}
+		})
+	}
+}
+
+func TestExtractNoExample(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		part int
+	}{
+		{"no section for the part", fenced, 2},
+		{"no section at all", "For example:\n\n```\n1\n```\n\nThe total is _`1`_.\n", 1},
+		{"no answer", "### Part One\n\nFor example:\n\n```\n1\n```\n", 1},
+		{"no example input", "### Part One\n\nThe total is _`1`_.\n\n```\n1\n```\n", 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if ex, err := Extract(tc.md, tc.part, DefaultPrefix); !errors.Is(err, ErrNoExample) {
+				t.Errorf("Extract = %+v, %v, want ErrNoExample", ex, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package input retrieves the puzzle inputs of the Advent of Code.
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// DefaultInterval is the minimum delay between two requests to the website.
+const DefaultInterval = 5 * time.Second
+
+// userAgent identifies the tool to the website, as requested by the Advent of Code author.
+const userAgent = "github.com/cl3mcg/aoc2024 input fetcher"
+
+// ErrNoSession is returned when no session token can be found.
+var ErrNoSession = errors.New("no session token: set AOC_SESSION or write it to the session file")
+
+// Fetcher downloads puzzle inputs and keeps them in an on-disk cache.
+// A cached input is never downloaded again.
+type Fetcher struct {
+	BaseURL  string        // BaseURL is the address of the website, DefaultBaseURL if empty.
+	Session  string        // Session is the value of the "session" cookie of a logged-in user.
+	CacheDir string        // CacheDir is the root of the cache, holding one file per year and day.
+	Interval time.Duration // Interval is the minimum delay between two requests, across runs of the program.
+	Client   *http.Client  // Client performs the requests, http.DefaultClient if nil.
+}
+
+// NewFetcher returns a fetcher using the default base URL and interval, the per-user cache directory
+// and the session token found by Session.
+func NewFetcher() (*Fetcher, error) {
+	dir, err := DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	s, err := Session()
+	if err != nil && !errors.Is(err, ErrNoSession) {
+		return nil, err
+	}
+	return &Fetcher{BaseURL: DefaultBaseURL, Session: s, CacheDir: dir, Interval: DefaultInterval}, nil
+}
+
+// DefaultCacheDir returns the per-user cache directory of the inputs, e.g. ~/.cache/aoc on Linux.
+func DefaultCacheDir() (string, error) {
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "aoc"), nil
+}
+
+// SessionFile returns the path of the file that can hold the session token, e.g. ~/.config/aoc/session on Linux.
+func SessionFile() (string, error) {
+	d, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "aoc", "session"), nil
+}
+
+// Session returns the session token, taken from the AOC_SESSION environment variable
+// or else from the session file.
+func Session() (string, error) {
+	if s := strings.TrimSpace(os.Getenv("AOC_SESSION")); s != "" {
+		return s, nil
+	}
+	p, err := SessionFile()
+	if err != nil {
+		return "", err
+	}
+	d, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if err != nil {
+		return "", err
+	}
+	if s := strings.TrimSpace(string(d)); s != "" {
+		return s, nil
+	}
+	return "", ErrNoSession
+}
+
+// CachePath returns the path of the cached input of a day, keyed by year and day.
+func (f *Fetcher) CachePath(year, day int) string {
+	return filepath.Join(f.CacheDir, strconv.Itoa(year), fmt.Sprintf("day%02d.txt", day))
+}
+
+// Fetch returns the input of a day, from the cache if present, or else downloaded and then cached.
+//
+// ctx: The context of the request.
+// year: The year of the event, e.g. 2024.
+// day: The day of the puzzle, from 1 to 25.
+// Returns: The content of the input, or an error if it is not cached and cannot be downloaded.
+func (f *Fetcher) Fetch(ctx context.Context, year, day int) (string, error) {
+	if day < 1 || day > 25 {
+		return "", fmt.Errorf("invalid day %d, expected 1 to 25", day)
+	}
+
+	// Never download an input that is already cached.
+	p := f.CachePath(year, day)
+	if d, err := os.ReadFile(p); err == nil {
+		return string(d), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	if f.Session == "" {
+		return "", ErrNoSession
+	}
+	if err := f.wait(ctx); err != nil {
+		return "", err
+	}
+
+	base := f.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(base, "/"), year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+	req.Header.Set("User-Agent", userAgent)
+
+	c := f.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	d, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s: %s: %s", url, res.Status, strings.TrimSpace(string(d)))
+	}
+
+	// Write the cache through a temporary file so that an interrupted write never leaves a partial input.
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return "", err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, d, 0o600); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		return "", err
+	}
+	return string(d), nil
+}
+
+// wait enforces the minimum interval between two requests. The time of the last request is kept
+// in the cache directory, so that the limit also holds across successive runs of the program.
+func (f *Fetcher) wait(ctx context.Context) error {
+	stamp := filepath.Join(f.CacheDir, ".last-request")
+	if fi, err := os.Stat(stamp); err == nil {
+		if d := f.Interval - time.Since(fi.ModTime()); d > 0 {
+			t := time.NewTimer(d)
+			defer t.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-t.C:
+			}
+		}
+	}
+
+	if err := os.MkdirAll(f.CacheDir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(stamp, nil, 0o600)
+}
@@ -0,0 +1,123 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// standIn starts a stand-in server serving the inputs of days 7 and 8, and returns it with
+// the number of requests it has received.
+func standIn(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	root := t.TempDir()
+	for day, txt := range map[string]string{"day07": "7 7 7\n", "day08": "8 8 8\n"} {
+		if err := os.MkdirAll(filepath.Join(root, day), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, day, "input.txt"), []byte(txt), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var n atomic.Int32
+	h := StandInHandler(root)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		if ua := r.Header.Get("User-Agent"); ua != userAgent {
+			t.Errorf("User-Agent = %q, want %q", ua, userAgent)
+		}
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &n
+}
+
+// newFetcher returns a fetcher of the stand-in server with an empty cache and no interval.
+func newFetcher(t *testing.T, srv *httptest.Server) *Fetcher {
+	return &Fetcher{BaseURL: srv.URL, Session: "secret", CacheDir: t.TempDir(), Client: srv.Client()}
+}
+
+func TestFetchCache(t *testing.T) {
+	srv, n := standIn(t)
+	f := newFetcher(t, srv)
+
+	for range 2 {
+		got, err := f.Fetch(context.Background(), 2024, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "7 7 7\n" {
+			t.Errorf("Fetch = %q, want %q", got, "7 7 7\n")
+		}
+	}
+	if got := n.Load(); got != 1 {
+		t.Errorf("%d requests for two fetches of the same day, want 1", got)
+	}
+	if _, err := os.Stat(f.CachePath(2024, 7)); err != nil {
+		t.Errorf("input not cached: %v", err)
+	}
+}
+
+func TestFetchNoSession(t *testing.T) {
+	srv, n := standIn(t)
+	f := newFetcher(t, srv)
+	f.Session = ""
+
+	if _, err := f.Fetch(context.Background(), 2024, 7); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Fetch without session: err = %v, want ErrNoSession", err)
+	}
+	if got := n.Load(); got != 0 {
+		t.Errorf("%d requests without session, want 0", got)
+	}
+}
+
+func TestFetchErrorNotCached(t *testing.T) {
+	srv, n := standIn(t)
+	f := newFetcher(t, srv)
+
+	// The stand-in server has no input for day 9 and answers 404 Not Found.
+	for range 2 {
+		if _, err := f.Fetch(context.Background(), 2024, 9); err == nil {
+			t.Fatal("Fetch of a missing day succeeded")
+		}
+	}
+	if got := n.Load(); got != 2 {
+		t.Errorf("%d requests for two failed fetches, want 2 since errors are not cached", got)
+	}
+	if _, err := os.Stat(f.CachePath(2024, 9)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("failed fetch cached: %v", err)
+	}
+}
+
+func TestFetchInterval(t *testing.T) {
+	srv, _ := standIn(t)
+	f := newFetcher(t, srv)
+	f.Interval = 300 * time.Millisecond
+
+	if _, err := f.Fetch(context.Background(), 2024, 7); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := f.Fetch(context.Background(), 2024, 8); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < f.Interval-50*time.Millisecond {
+		t.Errorf("second request sent after %v, want at least %v", d, f.Interval)
+	}
+
+	// The interval also holds for another fetcher sharing the cache, and ends early with the context.
+	g := *f
+	g.Interval = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := g.Fetch(ctx, 2024, 9); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fetch during the interval: err = %v, want context.DeadlineExceeded", err)
+	}
+}
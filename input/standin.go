@@ -0,0 +1,34 @@
+package input
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// StandInHandler serves puzzle inputs like the Advent of Code website does, at /{year}/day/{day}/input,
+// so that the fetcher can be tried without reaching the real website. The inputs are read from the
+// day directories of this repository: root/dayXX/input.txt or the vault, whatever the year.
+// Requests without a session cookie are rejected with 400 Bad Request, as the website does.
+func StandInHandler(root string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{year}/day/{day}/input", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err != nil || c.Value == "" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		day, err := strconv.Atoi(r.PathValue("day"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		d, err := Read(root, day)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, d)
+	})
+	return mux
+}
@@ -0,0 +1,165 @@
+package input
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// The puzzle inputs must not be redistributed, so the repository can keep them encrypted instead:
+// dayXX/input.vault holds the input of a day, sealed with AES-256-GCM under a key derived from a passphrase
+// with scrypt. The layout of a vault file is:
+//
+//	magic (8 bytes) | log2 n, r, p (1 byte each) | salt (16 bytes) | nonce (12 bytes) | ciphertext and tag
+//
+// The header and the name of the day are authenticated with the ciphertext, so a vault file
+// cannot be tampered with or moved to another day without being detected.
+
+// vaultMagic starts every vault file, with the version of the format.
+const vaultMagic = "aocvlt01"
+
+// The scrypt parameters of new vault files: 32 MiB of memory, about a tenth of a second per input.
+const (
+	vaultLogN = 15
+	vaultR    = 8
+	vaultP    = 1
+)
+
+// vaultHeaderLen is the length of the header, up to the ciphertext.
+const vaultHeaderLen = len(vaultMagic) + 3 + 16 + 12
+
+var (
+	// ErrNoPassphrase is returned when an input must be decrypted and no passphrase can be found.
+	ErrNoPassphrase = errors.New("no vault passphrase: set AOC_VAULT_PASSPHRASE or write it to the passphrase file")
+	// ErrVault is returned when a vault file cannot be decrypted: wrong passphrase, damaged or moved file.
+	ErrVault = errors.New("cannot open the vault: wrong passphrase or damaged file")
+)
+
+// PassphraseFile returns the path of the file that can hold the vault passphrase, e.g. ~/.config/aoc/vault on Linux.
+func PassphraseFile() (string, error) {
+	d, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "aoc", "vault"), nil
+}
+
+// Passphrase returns the vault passphrase, taken from the AOC_VAULT_PASSPHRASE environment variable
+// or else from the passphrase file.
+func Passphrase() (string, error) {
+	if s := os.Getenv("AOC_VAULT_PASSPHRASE"); s != "" {
+		return s, nil
+	}
+	p, err := PassphraseFile()
+	if err != nil {
+		return "", err
+	}
+	d, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoPassphrase
+	}
+	if err != nil {
+		return "", err
+	}
+	if s := strings.TrimRight(string(d), "\r\n"); s != "" {
+		return s, nil
+	}
+	return "", ErrNoPassphrase
+}
+
+// Seal encrypts an input for the vault.
+//
+// plain: The content of the input.
+// pass: The passphrase.
+// name: The name the file is bound to, e.g. "day07". Open must be given the same name.
+// Returns: The content of the vault file.
+func Seal(plain []byte, pass, name string) ([]byte, error) {
+	h := make([]byte, vaultHeaderLen)
+	copy(h, vaultMagic)
+	h[8], h[9], h[10] = vaultLogN, vaultR, vaultP
+	if _, err := rand.Read(h[11:]); err != nil {
+		return nil, err
+	}
+	gcm, err := vaultCipher(h, pass)
+	if err != nil {
+		return nil, err
+	}
+	nonce := h[len(h)-gcm.NonceSize():]
+	return gcm.Seal(h, nonce, plain, append(h[:len(h):len(h)], name...)), nil
+}
+
+// Open decrypts a vault file sealed by Seal with the same passphrase and name.
+func Open(sealed []byte, pass, name string) ([]byte, error) {
+	if len(sealed) < vaultHeaderLen || !bytes.HasPrefix(sealed, []byte(vaultMagic)) {
+		return nil, fmt.Errorf("%s: %w", name, ErrVault)
+	}
+	h := sealed[:vaultHeaderLen]
+	gcm, err := vaultCipher(h, pass)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	nonce := h[len(h)-gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed[vaultHeaderLen:], append(h[:len(h):len(h)], name...))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, ErrVault)
+	}
+	return plain, nil
+}
+
+// vaultCipher derives the key of a vault file from the passphrase and the parameters of its header.
+func vaultCipher(h []byte, pass string) (cipher.AEAD, error) {
+	logN, r, p := h[8], int(h[9]), int(h[10])
+	// Only the parameters written by Seal are accepted, so that a crafted file cannot make the derivation
+	// take gigabytes of memory. The header keeps them to let a later version of the format raise them.
+	if logN != vaultLogN || r != vaultR || p != vaultP {
+		return nil, ErrVault
+	}
+	salt := h[11 : 11+16]
+	key, err := scrypt.Key([]byte(pass), salt, 1<<logN, r, p, 32)
+	if err != nil {
+		return nil, err
+	}
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(b)
+}
+
+// VaultPath returns the path of the vault file of a day: root/dayXX/input.vault.
+func VaultPath(root string, day int) string {
+	return filepath.Join(root, fmt.Sprintf("day%02d", day), "input.vault")
+}
+
+// Read returns the input of a day from the repository: root/dayXX/input.txt if it is there,
+// or else the vault file of the day, decrypted with the passphrase found by Passphrase.
+func Read(root string, day int) (string, error) {
+	name := fmt.Sprintf("day%02d", day)
+	d, err := os.ReadFile(filepath.Join(root, name, "input.txt"))
+	if !errors.Is(err, os.ErrNotExist) {
+		return string(d), err
+	}
+
+	sealed, verr := os.ReadFile(VaultPath(root, day))
+	if errors.Is(verr, os.ErrNotExist) {
+		// Neither file exists, report the missing plain input which is the usual one.
+		return "", err
+	}
+	if verr != nil {
+		return "", verr
+	}
+	pass, err := Passphrase()
+	if err != nil {
+		return "", err
+	}
+	plain, err := Open(sealed, pass, name)
+	return string(plain), err
+}
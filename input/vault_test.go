@@ -0,0 +1,92 @@
+package input
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	plain := []byte("3   4\n4   3\n2   5\n")
+	sealed, err := Seal(plain, "correct horse", "day01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Open(sealed, "correct horse", "day01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(plain) {
+		t.Errorf("Open = %q, want %q", got, plain)
+	}
+
+	if _, err := Open(sealed, "wrong horse", "day01"); !errors.Is(err, ErrVault) {
+		t.Errorf("Open with a wrong passphrase: err = %v, want ErrVault", err)
+	}
+	if _, err := Open(sealed, "correct horse", "day02"); !errors.Is(err, ErrVault) {
+		t.Errorf("Open of a file moved to another day: err = %v, want ErrVault", err)
+	}
+	if _, err := Open(sealed[:vaultHeaderLen-1], "correct horse", "day01"); !errors.Is(err, ErrVault) {
+		t.Errorf("Open of a truncated file: err = %v, want ErrVault", err)
+	}
+}
+
+func TestOpenTampered(t *testing.T) {
+	sealed, err := Seal([]byte("MMMSXXMASM\n"), "correct horse", "day04")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Flip a byte of the magic, of each scrypt parameter, of the salt, of the nonce and of the ciphertext.
+	for _, i := range []int{0, 8, 9, 10, 11, vaultHeaderLen - 1, vaultHeaderLen} {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 1
+		if _, err := Open(tampered, "correct horse", "day04"); !errors.Is(err, ErrVault) {
+			t.Errorf("Open with byte %d flipped: err = %v, want ErrVault", i, err)
+		}
+	}
+}
+
+func TestOpenCostlyParameters(t *testing.T) {
+	sealed, err := Seal([]byte("3   4\n"), "correct horse", "day01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A header asking for 2 GiB of memory is refused before any derivation.
+	sealed[8], sealed[9], sealed[10] = 20, 16, 16
+	if _, err := Open(sealed, "correct horse", "day01"); !errors.Is(err, ErrVault) {
+		t.Errorf("Open with logN=20, r=16, p=16: err = %v, want ErrVault", err)
+	}
+}
+
+func TestReadVault(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "day07"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := Seal([]byte("190: 10 19\n"), "correct horse", "day07")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(VaultPath(root, 7), sealed, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without input.txt, the input is decrypted from the vault with the passphrase of the environment.
+	t.Setenv("AOC_VAULT_PASSPHRASE", "correct horse")
+	got, err := Read(root, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "190: 10 19\n" {
+		t.Errorf("Read = %q, want %q", got, "190: 10 19\n")
+	}
+
+	t.Setenv("AOC_VAULT_PASSPHRASE", "wrong horse")
+	if _, err := Read(root, 7); !errors.Is(err, ErrVault) {
+		t.Errorf("Read with a wrong passphrase: err = %v, want ErrVault", err)
+	}
+}
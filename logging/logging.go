@@ -0,0 +1,100 @@
+// Package logging sets up the structured logging of the solutions and tools, through log/slog.
+//
+// Every command registers the same flags: -v for debug records, -q for errors only,
+// and -log-format to choose between text and JSON records. The records go to the standard error,
+// the answers alone to the standard output.
+//
+// Solvers do not use a global logger: they find theirs in their context with FromContext,
+// so that a caller, e.g. a test, can capture their diagnostics.
+package logging
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Flags holds the logging options given on the command line.
+type Flags struct {
+	verbose bool
+	quiet   bool
+	format  format
+}
+
+// format is the -log-format flag, rejecting unknown formats when the flags are parsed.
+type format string
+
+func (f *format) String() string { return string(*f) }
+
+func (f *format) Set(s string) error {
+	if s != "text" && s != "json" {
+		return fmt.Errorf("unknown log format %q, expected text or json", s)
+	}
+	*f = format(s)
+	return nil
+}
+
+// NewFlags registers -v, -q and -log-format on the flag set.
+func NewFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{format: "text"}
+	fs.BoolVar(&f.verbose, "v", false, "log debug records too")
+	fs.BoolVar(&f.quiet, "q", false, "log errors only")
+	fs.Var(&f.format, "log-format", "format of the log records: text or json")
+	return f
+}
+
+// Level returns the minimum level of the records to log: debug with -v, error with -q, info otherwise.
+func (f *Flags) Level() slog.Level {
+	switch {
+	case f.verbose:
+		return slog.LevelDebug
+	case f.quiet:
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// Logger returns a logger writing the records to w with the level and format of the flags.
+func (f *Flags) Logger(w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: f.Level()}
+	if f.format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// Fatal logs a record at the error level and terminates the program with the exit status 1.
+func Fatal(l *slog.Logger, msg string, args ...any) {
+	l.Error(msg, args...)
+	os.Exit(1)
+}
+
+// key is the type of the context key of the logger.
+type key struct{}
+
+// NewContext returns a copy of ctx carrying the logger l.
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, key{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or a logger discarding every record if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(key{}).(*slog.Logger); ok {
+		return l
+	}
+	return discard
+}
+
+// discard is a logger dropping every record.
+var discard = slog.New(discardHandler{})
+
+// discardHandler is a slog.Handler that is never enabled.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
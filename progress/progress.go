@@ -0,0 +1,106 @@
+// Package progress lets long-running solvers report how far they are, and shows it to the user
+// without mixing it with the answers: as a bar redrawn in place on a terminal, or as periodic log records.
+//
+// A solver finds its Progress in its context and reports into it:
+//
+//	pr := progress.FromContext(ctx)
+//	pr.SetTotal(len(cells))
+//	for _, c := range cells {
+//		...
+//		pr.Add(1)
+//	}
+//
+// The methods do nothing on a nil *Progress, so the solver does not have to check whether anybody is watching.
+package progress
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Progress holds the progress of a task: how many units of work it has, how many are done, and a short note.
+// It is safe for concurrent use.
+type Progress struct {
+	mu    sync.Mutex
+	total int
+	done  int
+	note  string
+	start time.Time
+}
+
+// New creates the progress of a task starting now.
+func New() *Progress {
+	return &Progress{start: time.Now()}
+}
+
+// SetTotal sets the number of units of work of the task.
+func (p *Progress) SetTotal(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.total = n
+	p.mu.Unlock()
+}
+
+// Add records n more units of work done.
+func (p *Progress) Add(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.done += n
+	p.mu.Unlock()
+}
+
+// Note sets a short description of the current state of the task, e.g. "12 loops found".
+func (p *Progress) Note(s string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.note = s
+	p.mu.Unlock()
+}
+
+// Snapshot is the state of a Progress at a given time.
+type Snapshot struct {
+	Total   int           // Total is the number of units of work, zero if unknown.
+	Done    int           // Done is the number of units of work done.
+	Note    string        // Note is the last note of the task.
+	Elapsed time.Duration // Elapsed is the time since the task started.
+}
+
+// Snapshot returns the current state of the progress.
+func (p *Progress) Snapshot() Snapshot {
+	if p == nil {
+		return Snapshot{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Snapshot{Total: p.total, Done: p.done, Note: p.note, Elapsed: time.Since(p.start)}
+}
+
+// ETA estimates the time left from the average speed so far. It reports false when no estimate can be made yet.
+func (s Snapshot) ETA() (time.Duration, bool) {
+	if s.Total <= 0 || s.Done <= 0 {
+		return 0, false
+	}
+	left := time.Duration(float64(s.Elapsed) * float64(s.Total-s.Done) / float64(s.Done))
+	return max(left, 0), true
+}
+
+// key is the type of the context key of the progress.
+type key struct{}
+
+// NewContext returns a copy of ctx carrying the progress p.
+func NewContext(ctx context.Context, p *Progress) context.Context {
+	return context.WithValue(ctx, key{}, p)
+}
+
+// FromContext returns the progress carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Progress {
+	p, _ := ctx.Value(key{}).(*Progress)
+	return p
+}
@@ -0,0 +1,114 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// BarInterval is the delay between two redraws of the bar.
+const BarInterval = 100 * time.Millisecond
+
+// LogInterval is the delay between two log records of the progress.
+const LogInterval = 5 * time.Second
+
+// barWidth is the number of characters of the bar itself.
+const barWidth = 30
+
+// Show renders the progress on f, usually os.Stderr: as a bar if f is a terminal, or else as info records
+// of the logger. Nothing is shown for a task that does not report any progress.
+// stop must be called when the task is over; it erases the bar.
+func Show(f *os.File, l *slog.Logger, p *Progress, label string) (stop func()) {
+	if IsTerminal(f) {
+		return Bar(f, p, label)
+	}
+	return Log(l, p, label, LogInterval)
+}
+
+// IsTerminal reports whether f is a terminal rather than a file or a pipe.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Bar draws the progress on w as a single line redrawn in place, with the expected time left.
+// stop must be called when the task is over; it erases the line.
+func Bar(w io.Writer, p *Progress, label string) (stop func()) {
+	drawn := false
+	return every(BarInterval, func() {
+		s := p.Snapshot()
+		if s.Total <= 0 && s.Note == "" {
+			return
+		}
+		drawn = true
+		fmt.Fprintf(w, "\r\033[K%s", line(label, s))
+	}, func() {
+		if drawn {
+			fmt.Fprint(w, "\r\033[K")
+		}
+	})
+}
+
+// line formats a snapshot as a line of the bar, e.g. "day 06 part 2 [#####-----] 50% 8450/16900 ETA 1.2s: 700 loops found".
+func line(label string, s Snapshot) string {
+	var b strings.Builder
+	b.WriteString(label)
+	if s.Total > 0 {
+		n := min(barWidth*s.Done/s.Total, barWidth)
+		fmt.Fprintf(&b, " [%s%s] %3d%% %d/%d", strings.Repeat("#", n), strings.Repeat("-", barWidth-n), 100*s.Done/s.Total, s.Done, s.Total)
+	}
+	if eta, ok := s.ETA(); ok {
+		fmt.Fprintf(&b, " ETA %v", eta.Round(100*time.Millisecond))
+	}
+	if s.Note != "" {
+		fmt.Fprintf(&b, ": %s", s.Note)
+	}
+	return b.String()
+}
+
+// Log reports the progress as a record of the logger every interval.
+// stop must be called when the task is over.
+func Log(l *slog.Logger, p *Progress, label string, interval time.Duration) (stop func()) {
+	return every(interval, func() {
+		s := p.Snapshot()
+		if s.Total <= 0 && s.Note == "" {
+			return
+		}
+		attrs := []any{"task", label, "done", s.Done, "total", s.Total, "elapsed", s.Elapsed.Round(time.Millisecond)}
+		if eta, ok := s.ETA(); ok {
+			attrs = append(attrs, "eta", eta.Round(time.Millisecond))
+		}
+		if s.Note != "" {
+			attrs = append(attrs, "note", s.Note)
+		}
+		l.Info("progress", attrs...)
+	}, func() {})
+}
+
+// every calls tick at each interval until stop is called, then calls final.
+// stop waits for the last tick, so that nothing is drawn once it has returned.
+func every(interval time.Duration, tick, final func()) (stop func()) {
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-quit:
+				final()
+				return
+			case <-t.C:
+				tick()
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		<-done
+	}
+}
@@ -0,0 +1,98 @@
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Line is a line of a puzzle input with its number.
+type Line struct {
+	Num  int    // Num is the 1-based number of the line.
+	Text string // Text is the content of the line, without its line ending.
+}
+
+// Lines splits an input into lines, accepting both LF and CRLF line endings.
+// Blank lines are kept, so that the numbers match the ones of an editor.
+func Lines(txt string) []Line {
+	var ls []Line
+	for i, v := range strings.Split(txt, "\n") {
+		ls = append(ls, Line{Num: i + 1, Text: strings.TrimSuffix(v, "\r")})
+	}
+	// The final line ending does not start a new line.
+	if n := len(ls); n > 0 && ls[n-1].Text == "" {
+		ls = ls[:n-1]
+	}
+	return ls
+}
+
+// Blank reports whether the line holds only white space.
+func (l Line) Blank() bool {
+	return strings.TrimSpace(l.Text) == ""
+}
+
+// Field is a token of a line with its position.
+type Field struct {
+	Text   string // Text is the token.
+	Column int    // Column is the 1-based byte column of the first character of the token.
+}
+
+// Fields splits the line around each run of white space, like strings.Fields, keeping the columns.
+func (l Line) Fields() []Field {
+	var fs []Field
+	start := -1
+	for i := 0; i <= len(l.Text); i++ {
+		space := i == len(l.Text) || l.Text[i] == ' ' || l.Text[i] == '\t' || l.Text[i] == '\r'
+		switch {
+		case !space && start < 0:
+			start = i
+		case space && start >= 0:
+			fs = append(fs, Field{Text: l.Text[start:i], Column: start + 1})
+			start = -1
+		}
+	}
+	return fs
+}
+
+// Split splits the line around each separator, like strings.Split, keeping the columns.
+// The white space around the line is ignored, not the one around the separators.
+func (l Line) Split(sep string) []Field {
+	text := strings.TrimSpace(l.Text)
+	col := strings.Index(l.Text, text) + 1
+	var fs []Field
+	for {
+		i := strings.Index(text, sep)
+		if i < 0 {
+			return append(fs, Field{Text: text, Column: col})
+		}
+		fs = append(fs, Field{Text: text[:i], Column: col})
+		text = text[i+len(sep):]
+		col += i + len(sep)
+	}
+}
+
+// Error returns a *ParseError on the field of the line, describing the problem with err.
+func (l Line) Error(f Field, err error) *ParseError {
+	return &ParseError{Line: l.Num, Column: f.Column, Token: f.Text, Text: l.Text, Err: err}
+}
+
+// End returns an empty field just after the end of the line, to report a missing token.
+func (l Line) End() Field {
+	return Field{Column: len(strings.TrimRight(l.Text, " \t")) + 1}
+}
+
+// Atoi converts a field of the line to an int. If the field is not a number, it returns a *ParseError
+// on the field whose problem is what, e.g. "invalid location ID", followed by the reason.
+func (l Line) Atoi(f Field, what string) (int, error) {
+	n, err := strconv.Atoi(f.Text)
+	if err != nil {
+		// Keep the reason only, the token is already part of the ParseError.
+		var ne *strconv.NumError
+		if errors.As(err, &ne) {
+			err = ne.Err
+		}
+		return 0, l.Error(f, fmt.Errorf("%s: %w", what, err))
+	}
+	return n, nil
+}
@@ -0,0 +1,85 @@
+// Package puzzle holds the helpers shared by the parsers of the puzzle inputs:
+// the lines and fields of an input with their positions, and the ParseError they all return.
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrEmpty is reported by the validators when an input holds nothing but blank lines.
+var ErrEmpty = errors.New("the input is empty")
+
+// ParseError describes a malformed token of a puzzle input, with its position.
+type ParseError struct {
+	File   string // File is the path of the input, empty if unknown. See WithFile.
+	Line   int    // Line is the 1-based number of the offending line.
+	Column int    // Column is the 1-based byte column of the offending token in the line.
+	Token  string // Token is the offending token, empty when the error is about a missing token.
+	Text   string // Text is the whole offending line, without its line ending.
+	Err    error  // Err describes the problem, e.g. a sentinel error of the day.
+}
+
+// Error formats the error like a compiler does: "file:line:column: problem: token".
+func (e *ParseError) Error() string {
+	pos := fmt.Sprintf("%d:%d", e.Line, e.Column)
+	if e.File != "" {
+		pos = e.File + ":" + pos
+	}
+	if e.Token == "" {
+		return fmt.Sprintf("%s: %v", pos, e.Err)
+	}
+	return fmt.Sprintf("%s: %v: %q", pos, e.Err, e.Token)
+}
+
+// Unwrap returns the underlying error so that errors.Is and errors.As can inspect it.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// WithFile records the path of the input in err if it is or wraps a *ParseError, and returns err.
+// The parsers work on the content of the input and do not know where it comes from.
+func WithFile(err error, file string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.File = file
+	}
+	return err
+}
+
+// Render writes err to w. A *ParseError is followed by its Excerpt, like a compiler error:
+//
+//	day01/input.txt:3:5: invalid location ID: invalid syntax: "12a4"
+//	 3 | 2   12a4
+//	   |     ^~~~
+func Render(w io.Writer, err error) error {
+	if _, werr := fmt.Fprintln(w, err); werr != nil {
+		return werr
+	}
+	return Excerpt(w, err)
+}
+
+// Excerpt writes the offending line of err and a caret under the offending token, if err is or wraps
+// a *ParseError. It writes nothing for other errors.
+func Excerpt(w io.Writer, err error) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line == 0 {
+		return nil
+	}
+
+	// Keep the tabs of the line before the token so that the caret lines up with it.
+	col := min(max(pe.Column-1, 0), len(pe.Text))
+	pad := []byte(pe.Text[:col])
+	for i, c := range pad {
+		if c != '\t' {
+			pad[i] = ' '
+		}
+	}
+	marker := "^" + strings.Repeat("~", max(len(pe.Token)-1, 0))
+
+	margin := strings.Repeat(" ", len(fmt.Sprint(pe.Line)))
+	_, werr := fmt.Fprintf(w, " %d | %s\n %s | %s%s\n", pe.Line, pe.Text, margin, pad, marker)
+	return werr
+}
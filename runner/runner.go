@@ -0,0 +1,149 @@
+// Package runner keeps the registry of the puzzle solvers and input validators, and runs the solvers.
+//
+// Each day package registers its parts from an init function:
+//
+//	func init() {
+//		runner.Register(1, 1, Part1)
+//		runner.Register(1, 2, Part2)
+//		runner.RegisterValidator(1, Validate)
+//	}
+//
+// and is linked into the aoc command with a blank import in cmd/aoc/days.go.
+package runner
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Solver computes the answer of a part from the content of the puzzle input.
+// Slow solvers should return early with an error wrapping ctx.Err() once ctx is done,
+// saying how far they went.
+type Solver func(ctx context.Context, input string) (any, error)
+
+// Part is a registered solver.
+type Part struct {
+	Day   int    // Day is the day of the puzzle, from 1 to 25.
+	Part  int    // Part is 1 or 2.
+	Solve Solver // Solve computes the answer.
+}
+
+var (
+	mu         sync.Mutex
+	parts      []Part
+	validators = make(map[int]Validator)
+)
+
+// Register adds the solver of a part to the registry. It panics if the part is already registered,
+// since that can only be a mistake in the registration code.
+func Register[T any](day, part int, solve func(ctx context.Context, input string) (T, error)) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if slices.ContainsFunc(parts, func(p Part) bool { return p.Day == day && p.Part == part }) {
+		panic(fmt.Sprintf("runner: day %d part %d registered twice", day, part))
+	}
+	parts = append(parts, Part{Day: day, Part: part, Solve: func(ctx context.Context, input string) (any, error) {
+		return solve(ctx, input)
+	}})
+	slices.SortFunc(parts, func(a, b Part) int {
+		return cmp.Or(cmp.Compare(a.Day, b.Day), cmp.Compare(a.Part, b.Part))
+	})
+}
+
+// Parts returns the registered parts, sorted by day and part.
+func Parts() []Part {
+	mu.Lock()
+	defer mu.Unlock()
+	return slices.Clone(parts)
+}
+
+// Lookup returns the parts registered for a day, and only the given part if part is not zero.
+func Lookup(day, part int) []Part {
+	var ps []Part
+	for _, p := range Parts() {
+		if p.Day == day && (part == 0 || p.Part == part) {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// Validator checks an input against the format of a day and returns every problem found, in input order.
+// It returns nil if the input is well formed.
+type Validator func(input string) []error
+
+// RegisterValidator adds the validator of a day to the registry. It panics if the day already has one.
+func RegisterValidator(day int, v Validator) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := validators[day]; ok {
+		panic(fmt.Sprintf("runner: validator of day %d registered twice", day))
+	}
+	validators[day] = v
+}
+
+// LookupValidator returns the validator of a day, or false if the day has none.
+func LookupValidator(day int) (Validator, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	v, ok := validators[day]
+	return v, ok
+}
+
+// Outcome is the result of running a part.
+type Outcome struct {
+	Part
+	Answer      any           // Answer is the value returned by the solver.
+	Err         error         // Err is the error returned by the solver, or the panic it raised.
+	Panicked    bool          // Panicked is true if Err comes from a panic.
+	Interrupted bool          // Interrupted is true if the part was stopped by its context before giving an answer.
+	Elapsed     time.Duration // Elapsed is the time taken by the solver.
+}
+
+// Grace is how long Run waits for a solver to return by itself once its context is done.
+const Grace = 250 * time.Millisecond
+
+// ErrAbandoned is returned when a solver ignores the end of its context for longer than Grace.
+var ErrAbandoned = errors.New("the solver did not stop and was abandoned")
+
+// Run solves a part, turning a panic of the solver into an error.
+// Once ctx is done, the solver has Grace to return, usually with an error telling how far it went.
+// After that Run returns anyway and leaves the solver running in the background.
+func Run(ctx context.Context, p Part, input string) Outcome {
+	start := time.Now()
+	done := make(chan Outcome, 1)
+	go func() {
+		o := Outcome{Part: p}
+		defer func() {
+			if r := recover(); r != nil {
+				o.Err = fmt.Errorf("panic: %v", r)
+				o.Panicked = true
+			}
+			done <- o
+		}()
+		o.Answer, o.Err = p.Solve(ctx, input)
+	}()
+
+	var o Outcome
+	select {
+	case o = <-done:
+	case <-ctx.Done():
+		t := time.NewTimer(Grace)
+		defer t.Stop()
+		select {
+		case o = <-done:
+		case <-t.C:
+			o = Outcome{Part: p, Err: fmt.Errorf("%w: %w", ErrAbandoned, ctx.Err())}
+		}
+	}
+	o.Interrupted = o.Err != nil && ctx.Err() != nil
+	o.Elapsed = time.Since(start)
+	return o
+}